@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/url"
+)
+
+// Alarm - alarm raised by nsx health framework, alarms api is not covered by vendored sdks
+type Alarm struct {
+	ID                 string `json:"id"`
+	FeatureName        string `json:"feature_name"`
+	EventType          string `json:"event_type"`
+	Severity           string `json:"severity"`
+	Status             string `json:"status"`
+	NodeID             string `json:"node_id"`
+	NodeResourceType   string `json:"node_resource_type"`
+	EntityID           string `json:"entity_id"`
+	EntityResourceType string `json:"entity_resource_type"`
+	Summary            string `json:"summary"`
+	LastReportedTime   int64  `json:"last_reported_time"`
+}
+
+var alarmSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+type alarmListResult struct {
+	Cursor  string  `json:"cursor"`
+	Results []Alarm `json:"results"`
+}
+
+// ListOpenAlarms - lists open alarms matching feature and severity filters
+func (a *NSXApi) ListOpenAlarms() ([]Alarm, error) {
+	a.log.Debugf("fetching open alarms list")
+	res := []Alarm{}
+	query := url.Values{}
+	query.Set("status", "OPEN")
+
+	for {
+		alarms := alarmListResult{}
+		if err := a.getJSON("/api/v1/alarms", query, &alarms); err != nil {
+			a.log.WithError(err).Errorf("could not list alarms")
+			return nil, err
+		}
+
+		for _, cAlarm := range alarms.Results {
+			if !match(a.config.AlarmFeatureFilters, cAlarm.FeatureName) {
+				continue
+			}
+			if !match(a.config.AlarmSeverityFilters, cAlarm.Severity) {
+				continue
+			}
+			res = append(res, cAlarm)
+		}
+
+		if alarms.Cursor == "" {
+			break
+		}
+		query.Set("cursor", alarms.Cursor)
+	}
+
+	return res, nil
+}
+
+// AlarmSeverities - alarm severities matching severity filters
+func (a *NSXApi) AlarmSeverities() []string {
+	res := []string{}
+	for _, cSeverity := range alarmSeverities {
+		if match(a.config.AlarmSeverityFilters, cSeverity) {
+			res = append(res, cSeverity)
+		}
+	}
+	return res
+}
@@ -15,18 +15,23 @@ import (
 	"sync"
 )
 
+// retryMaxDelay - maximum delay in milliseconds between two retries
+const retryMaxDelay = 500
+
 var (
 	retryCodes = []int{429, 503}
 	False      = false
+	True       = true
 	RealTime   = "realtime"
 )
 
 type NSXApi struct {
 	sync.Mutex
-	config    *config.NSXConfig
-	connector *client.RestConnector
-	client    *nsxt.APIClient
-	log       *log.Entry
+	config     *config.NSXConfig
+	connector  *client.RestConnector
+	client     *nsxt.APIClient
+	httpClient *http.Client
+	log        *log.Entry
 }
 
 func NewNSXApi(config *config.NSXConfig) (*NSXApi, error) {
@@ -43,7 +48,7 @@ func NewNSXApi(config *config.NSXConfig) (*NSXApi, error) {
 	retriesConfig := nsxt.ClientRetriesConfiguration{
 		MaxRetries:      config.MaxRetries,
 		RetryMinDelay:   0,
-		RetryMaxDelay:   500,
+		RetryMaxDelay:   retryMaxDelay,
 		RetryOnStatuses: retryCodes,
 	}
 
@@ -82,6 +87,7 @@ func (a *NSXApi) initNSXPolicyConnector() error {
 	if err != nil {
 		return err
 	}
+	a.httpClient = httpClient
 	a.connector = client.NewRestConnector(
 		a.config.URL,
 		*httpClient,
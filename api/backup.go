@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/administration"
+)
+
+// BackupSchedule - vendored administration.BackupSchedule only carries resource_type,
+// interval and weekly schedule fields are decoded here
+type BackupSchedule struct {
+	ResourceType          string  `json:"resource_type"`
+	SecondsBetweenBackups int64   `json:"seconds_between_backups"`
+	DaysOfWeek            []int64 `json:"days_of_week"`
+	HourOfDay             int64   `json:"hour_of_day"`
+	MinuteOfHour          int64   `json:"minute_of_hour"`
+}
+
+type BackupConfig struct {
+	administration.BackupConfiguration
+	BackupSchedule *BackupSchedule `json:"backup_schedule,omitempty"`
+}
+
+type BackupInfo struct {
+	Config  BackupConfig
+	Status  administration.CurrentBackupOperationStatus
+	History administration.BackupOperationHistory
+}
+
+func (a *NSXApi) GetBackupInfo() (*BackupInfo, error) {
+	var err error
+	info := BackupInfo{}
+
+	a.log.Debugf("fetching backup configuration")
+	if err = a.getJSON("/api/v1/cluster/backups/config", nil, &info.Config); err != nil {
+		a.log.WithError(err).Errorf("could not fetch backup configuration")
+		return nil, err
+	}
+
+	a.log.Debugf("fetching backup status")
+	// nolint: bodyclose
+	info.Status, _, err = a.client.NsxComponentAdministrationApi.GetBackupStatus(a.client.Context)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch backup status")
+		return nil, err
+	}
+
+	a.log.Debugf("fetching backup history")
+	// nolint: bodyclose
+	info.History, _, err = a.client.NsxComponentAdministrationApi.GetBackupHistory(a.client.Context)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch backup history")
+		return nil, err
+	}
+
+	return &info, nil
+}
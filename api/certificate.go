@@ -0,0 +1,95 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/trust"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// ListCertificates - lists trust store certificates, manager api is used because policy
+// TlsCertificate does not tell which services use the certificate
+func (a *NSXApi) ListCertificates() ([]trust.Certificate, error) {
+	a.log.Debugf("fetching certificates list")
+	res := []trust.Certificate{}
+	opts := map[string]interface{}{
+		"details": true,
+	}
+
+	for {
+		// nolint: bodyclose
+		certs, _, err := a.client.NsxComponentAdministrationApi.GetCertificates(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list certificates")
+			return nil, err
+		}
+		res = append(res, certs.Results...)
+
+		if certs.Cursor == "" {
+			break
+		}
+		opts["cursor"] = certs.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getCertificate(certID string) (*model.TlsCertificate, error) {
+	a.log.Debugf("fetching certificate '%s'", certID)
+
+	cli := infra.NewCertificatesClient(a.connector)
+	cert, err := cli.Get(certID, &True)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch certificate '%s'", certID)
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (a *NSXApi) ListCaBundles() ([]model.CaBundle, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching ca bundles list")
+	res := []model.CaBundle{}
+	cli := infra.NewCabundlesClient(a.connector)
+
+	for {
+		bundles, err := cli.List(cursor, &True, nil, nil, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list ca bundles")
+			return nil, err
+		}
+		res = append(res, bundles.Results...)
+
+		cursor = bundles.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) ListCrls() ([]model.TlsCrl, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching crls list")
+	res := []model.TlsCrl{}
+	cli := infra.NewCrlsClient(a.connector)
+
+	for {
+		crls, err := cli.List(cursor, &True, nil, nil, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list crls")
+			return nil, err
+		}
+		res = append(res, crls.Results...)
+
+		cursor = crls.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
@@ -1,16 +1,83 @@
 package api
 
 import (
+	"fmt"
+
 	"github.com/vmware/go-vmware-nsxt/administration"
 )
 
-func (a *NSXApi) GetClusterStatus() (*administration.ClusterStatus, error) {
+// ClusterGroupMember - member of a management cluster group
+type ClusterGroupMember struct {
+	UUID   string `json:"member_uuid"`
+	FQDN   string `json:"member_fqdn"`
+	IP     string `json:"member_ip"`
+	Status string `json:"member_status"`
+}
+
+// ClusterGroup - management cluster group (DATASTORE, CLUSTER_BOOT_MANAGER, CONTROLLER,
+// MANAGER, HTTPS...) and the status of its members
+type ClusterGroup struct {
+	ID      string               `json:"group_id"`
+	Type    string               `json:"group_type"`
+	Status  string               `json:"group_status"`
+	Members []ClusterGroupMember `json:"members"`
+}
+
+// DetailedClusterStatus - detailed_cluster_status section of cluster status, it is not
+// covered by vendored administration.ClusterStatus
+type DetailedClusterStatus struct {
+	OverallStatus string         `json:"overall_status"`
+	Groups        []ClusterGroup `json:"groups"`
+}
+
+// ClusterStatus - vendored administration.ClusterStatus extended with detailed cluster status
+// so both are read from a single request
+type ClusterStatus struct {
+	administration.ClusterStatus
+	DetailedClusterStatus *DetailedClusterStatus `json:"detailed_cluster_status,omitempty"`
+}
+
+// NodeServiceInfo - runtime status of a service running on a management cluster node
+type NodeServiceInfo struct {
+	Name   string
+	Status administration.NodeServiceStatusProperties
+}
+
+func (a *NSXApi) GetClusterStatus() (*ClusterStatus, error) {
 	a.log.Debugf("fetching cluster status")
-	// nolint: bodyclose
-	status, _, err := a.client.NsxComponentAdministrationApi.ReadClusterStatus(a.client.Context, nil)
-	if err != nil {
+	status := ClusterStatus{}
+	if err := a.getJSON("/api/v1/cluster/status", nil, &status); err != nil {
 		a.log.WithError(err).Errorf("could not fetch cluster status")
 		return nil, err
 	}
 	return &status, nil
 }
+
+// GetClusterNodeServices - fetches status of services running on given management node, calls
+// are proxied by the cluster to the node, services which status can't be fetched are skipped and
+// the last error is returned with the others
+func (a *NSXApi) GetClusterNodeServices(nodeID string) ([]NodeServiceInfo, error) {
+	var lastErr error
+
+	a.log.Debugf("fetching services of cluster node '%s'", nodeID)
+	services := administration.NodeServicePropertiesListResult{}
+	path := fmt.Sprintf("/api/v1/cluster/%s/node/services", nodeID)
+	if err := a.getJSON(path, nil, &services); err != nil {
+		a.log.WithError(err).Errorf("could not list services of cluster node '%s'", nodeID)
+		return nil, err
+	}
+
+	res := []NodeServiceInfo{}
+	for _, cService := range services.Results {
+		info := NodeServiceInfo{
+			Name: cService.ServiceName,
+		}
+		if err := a.getJSON(fmt.Sprintf("%s/%s/status", path, cService.ServiceName), nil, &info.Status); err != nil {
+			a.log.WithError(err).Errorf("could not fetch status of service '%s' on cluster node '%s'", cService.ServiceName, nodeID)
+			lastErr = err
+			continue
+		}
+		res = append(res, info)
+	}
+	return res, lastErr
+}
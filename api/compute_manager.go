@@ -0,0 +1,123 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+type ComputeManagerInfo struct {
+	Manager     manager.ComputeManager
+	Status      manager.ComputeManagerStatus
+	State       manager.ConfigurationState
+	Collections int
+	Hosts       int
+}
+
+func (a *NSXApi) ListComputeManagers() ([]manager.ComputeManager, error) {
+	a.log.Debugf("fetching compute managers list")
+	res := []manager.ComputeManager{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		managers, _, err := a.client.FabricApi.ListComputeManagers(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list compute managers")
+			return nil, err
+		}
+		res = append(res, managers.Results...)
+
+		if managers.Cursor == "" {
+			break
+		}
+		opts["cursor"] = managers.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) GetComputeManagerInfo(cm manager.ComputeManager) (*ComputeManagerInfo, error) {
+	var err error
+
+	info := ComputeManagerInfo{
+		Manager: cm,
+	}
+
+	a.log.Debugf("fetching compute manager '%s' status", cm.Id)
+	// nolint: bodyclose
+	info.Status, _, err = a.client.FabricApi.ReadComputeManagerStatus(a.client.Context, cm.Id)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch compute manager '%s' status", cm.Id)
+		return nil, err
+	}
+
+	a.log.Debugf("fetching compute manager '%s' state", cm.Id)
+	// nolint: bodyclose
+	info.State, _, err = a.client.FabricApi.GetComputeManagerState(a.client.Context, cm.Id)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch compute manager '%s' state", cm.Id)
+		return nil, err
+	}
+
+	info.Collections, err = a.countComputeCollections(cm.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Hosts, err = a.countDiscoveredHosts(cm.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func (a *NSXApi) countComputeCollections(cmID string) (int, error) {
+	a.log.Debugf("fetching compute collections of compute manager '%s'", cmID)
+	count := 0
+	opts := map[string]interface{}{
+		"originId": cmID,
+	}
+
+	for {
+		// nolint: bodyclose
+		collections, _, err := a.client.FabricApi.ListComputeCollections(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list compute collections of compute manager '%s'", cmID)
+			return 0, err
+		}
+		count += len(collections.Results)
+
+		if collections.Cursor == "" {
+			break
+		}
+		opts["cursor"] = collections.Cursor
+	}
+
+	return count, nil
+}
+
+func (a *NSXApi) countDiscoveredHosts(cmID string) (int, error) {
+	a.log.Debugf("fetching discovered hosts of compute manager '%s'", cmID)
+	count := 0
+	opts := map[string]interface{}{
+		"originId": cmID,
+		"nodeType": "HostNode",
+	}
+
+	for {
+		// nolint: bodyclose
+		nodes, _, err := a.client.FabricApi.ListDiscoveredNodes(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list discovered hosts of compute manager '%s'", cmID)
+			return 0, err
+		}
+		count += len(nodes.Results)
+
+		if nodes.Cursor == "" {
+			break
+		}
+		opts["cursor"] = nodes.Cursor
+	}
+
+	return count, nil
+}
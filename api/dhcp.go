@@ -0,0 +1,258 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/manager"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/dhcp_server_configs"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// DHCPServerInfo - statistics of a dhcp server, ConnectivityPath is the policy path of the
+// gateway or segment the server is attached to, empty when read from the legacy manager api
+type DHCPServerInfo struct {
+	ID               string
+	Name             string
+	ConnectivityPath string
+	Status           model.DhcpServerStatus
+	Stats            model.DhcpServerStatistics
+}
+
+// DHCPRelayInfo - dhcp relay attached to a gateway or segment, policy api gives no relay
+// statistics
+type DHCPRelayInfo struct {
+	ID               string
+	Name             string
+	ConnectivityPath string
+	ServerAddresses  []string
+}
+
+// GetDHCPServerInfos - fetches statistics of policy dhcp servers attached to given gateways
+// and segments, falls back to legacy manager dhcp servers when policy api gives nothing,
+// servers that can't be fetched are skipped and the last error is returned with the others
+func (a *NSXApi) GetDHCPServerInfos(t0s []model.Tier0, t1s []model.Tier1, segments []model.Segment) ([]DHCPServerInfo, error) {
+	var lastErr error
+
+	configs, err := a.listDHCPServerConfigs()
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		a.log.Debugf("no policy dhcp server found, falling back to manager api")
+		return a.getLegacyDHCPServerInfos()
+	}
+
+	paths := dhcpConnectivityPaths(t0s, t1s, segments)
+	res := []DHCPServerInfo{}
+	for _, cConfig := range configs {
+		for _, cPath := range paths[*cConfig.Path] {
+			info := DHCPServerInfo{
+				ID:               *cConfig.Id,
+				Name:             *cConfig.DisplayName,
+				ConnectivityPath: cPath,
+			}
+			info.Status, err = a.getDHCPServerStatus(*cConfig.Id, cPath)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			info.Stats, err = a.getDHCPServerStats(*cConfig.Id, cPath)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			res = append(res, info)
+		}
+	}
+	return res, lastErr
+}
+
+// GetDHCPRelayInfos - fetches dhcp relays attached to given gateways and segments
+func (a *NSXApi) GetDHCPRelayInfos(t0s []model.Tier0, t1s []model.Tier1, segments []model.Segment) ([]DHCPRelayInfo, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching dhcp relay configs list")
+	configs := []model.DhcpRelayConfig{}
+	cli := infra.NewDhcpRelayConfigsClient(a.connector)
+
+	for {
+		values, err := cli.List(cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list dhcp relay configs")
+			return nil, err
+		}
+		configs = append(configs, values.Results...)
+
+		cursor = values.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	paths := dhcpConnectivityPaths(t0s, t1s, segments)
+	res := []DHCPRelayInfo{}
+	for _, cConfig := range configs {
+		for _, cPath := range paths[*cConfig.Path] {
+			res = append(res, DHCPRelayInfo{
+				ID:               *cConfig.Id,
+				Name:             *cConfig.DisplayName,
+				ConnectivityPath: cPath,
+				ServerAddresses:  cConfig.ServerAddresses,
+			})
+		}
+	}
+	return res, nil
+}
+
+// dhcpConnectivityPaths - connectivity paths (gateways or segments) indexed by dhcp server or
+// relay config path
+func dhcpConnectivityPaths(t0s []model.Tier0, t1s []model.Tier1, segments []model.Segment) map[string][]string {
+	paths := map[string][]string{}
+	for _, cT0 := range t0s {
+		for _, cPath := range cT0.DhcpConfigPaths {
+			paths[cPath] = append(paths[cPath], *cT0.Path)
+		}
+	}
+	for _, cT1 := range t1s {
+		for _, cPath := range cT1.DhcpConfigPaths {
+			paths[cPath] = append(paths[cPath], *cT1.Path)
+		}
+	}
+	for _, cSegment := range segments {
+		if cSegment.DhcpConfigPath != nil {
+			paths[*cSegment.DhcpConfigPath] = append(paths[*cSegment.DhcpConfigPath], *cSegment.Path)
+		}
+	}
+	return paths
+}
+
+func (a *NSXApi) listDHCPServerConfigs() ([]model.DhcpServerConfig, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching dhcp server configs list")
+	res := []model.DhcpServerConfig{}
+	cli := infra.NewDhcpServerConfigsClient(a.connector)
+
+	for {
+		configs, err := cli.List(cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list dhcp server configs")
+			return nil, err
+		}
+		res = append(res, configs.Results...)
+
+		cursor = configs.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getDHCPServerStatus(configID string, path string) (model.DhcpServerStatus, error) {
+	a.log.Debugf("fetching status of dhcp server '%s' on '%s'", configID, path)
+
+	cli := dhcp_server_configs.NewStatusClient(a.connector)
+	status, err := cli.Get(configID, path, nil, nil, &False, nil, nil, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dhcp server '%s' status on '%s'", configID, path)
+		return status, err
+	}
+	return status, nil
+}
+
+func (a *NSXApi) getDHCPServerStats(configID string, path string) (model.DhcpServerStatistics, error) {
+	a.log.Debugf("fetching statistics of dhcp server '%s' on '%s'", configID, path)
+
+	cli := dhcp_server_configs.NewStatsClient(a.connector)
+	stats, err := cli.Get(configID, path, nil, nil, &False, nil, nil, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dhcp server '%s' statistics on '%s'", configID, path)
+		return stats, err
+	}
+	return stats, nil
+}
+
+// getLegacyDHCPServerInfos - servers that can't be fetched are skipped and the last error is
+// returned with the others
+func (a *NSXApi) getLegacyDHCPServerInfos() ([]DHCPServerInfo, error) {
+	var lastErr error
+
+	a.log.Debugf("fetching manager dhcp servers list")
+	res := []DHCPServerInfo{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		servers, _, err := a.client.ServicesApi.ListDhcpServers(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list manager dhcp servers")
+			return nil, err
+		}
+
+		for _, cServer := range servers.Results {
+			// nolint: bodyclose
+			status, _, err := a.client.ServicesApi.GetDhcpStatus(a.client.Context, cServer.Id)
+			if err != nil {
+				a.log.WithError(err).Errorf("could not fetch manager dhcp server '%s' status", cServer.Id)
+				lastErr = err
+				continue
+			}
+			// nolint: bodyclose
+			stats, _, err := a.client.ServicesApi.GetDhcpStatistics(a.client.Context, cServer.Id)
+			if err != nil {
+				a.log.WithError(err).Errorf("could not fetch manager dhcp server '%s' statistics", cServer.Id)
+				lastErr = err
+				continue
+			}
+			res = append(res, DHCPServerInfo{
+				ID:     cServer.Id,
+				Name:   cServer.DisplayName,
+				Status: legacyDHCPStatus(status),
+				Stats:  legacyDHCPStats(stats),
+			})
+		}
+
+		if servers.Cursor == "" {
+			break
+		}
+		opts["cursor"] = servers.Cursor
+	}
+
+	return res, lastErr
+}
+
+func legacyDHCPStatus(status manager.DhcpServerStatus) model.DhcpServerStatus {
+	return model.DhcpServerStatus{
+		ActiveNode:    &status.ActiveNode,
+		ErrorMessage:  &status.ErrorMessage,
+		ServiceStatus: &status.ServiceStatus,
+		StandByNode:   &status.StandByNode,
+	}
+}
+
+func legacyDHCPStats(stats manager.DhcpStatistics) model.DhcpServerStatistics {
+	res := model.DhcpServerStatistics{
+		Acks:         &stats.Acks,
+		Declines:     &stats.Declines,
+		DhcpServerId: &stats.DhcpServerId,
+		Discovers:    &stats.Discovers,
+		Errors:       &stats.Errors,
+		Informs:      &stats.Informs,
+		Nacks:        &stats.Nacks,
+		Offers:       &stats.Offers,
+		Releases:     &stats.Releases,
+		Requests:     &stats.Requests,
+		Timestamp:    &stats.Timestamp,
+	}
+	for idx := range stats.IpPoolStats {
+		cPool := &stats.IpPoolStats[idx]
+		res.IpPoolStats = append(res.IpPoolStats, model.DhcpIpPoolUsage{
+			AllocatedNumber:     &cPool.AllocatedNumber,
+			AllocatedPercentage: &cPool.AllocatedPercentage,
+			DhcpIpPoolId:        &cPool.DhcpIpPoolId,
+			PoolSize:            &cPool.PoolSize,
+		})
+	}
+	return res
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s"
+	t0DNSForwarder "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/dns_forwarder"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s"
+	t1DNSForwarder "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/dns_forwarder"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+type DNSForwarderInfo struct {
+	GatewayID string
+	Config    model.PolicyDnsForwarder
+	Status    *model.NsxTDNSForwarderStatus
+	Stats     *model.NsxTDNSForwarderStatistics
+}
+
+// dnsForwarderClients - tier0 and tier1 dns forwarder clients share the same signatures, gather them
+// to implement the logic only once
+type dnsForwarderClients struct {
+	config interface {
+		Get(string) (model.PolicyDnsForwarder, error)
+	}
+	status interface {
+		Get(string, *string) (model.AggregateDNSForwarderStatus, error)
+	}
+	stats interface {
+		Get(string, *string) (model.AggregateDNSForwarderStatistics, error)
+	}
+}
+
+func (a *NSXApi) GetT0DNSForwarderInfo(tierID string) (*DNSForwarderInfo, error) {
+	return a.getDNSForwarderInfo(tierID, dnsForwarderClients{
+		config: tier_0s.NewDnsForwarderClient(a.connector),
+		status: t0DNSForwarder.NewStatusClient(a.connector),
+		stats:  t0DNSForwarder.NewStatisticsClient(a.connector),
+	})
+}
+
+func (a *NSXApi) GetT1DNSForwarderInfo(tierID string) (*DNSForwarderInfo, error) {
+	return a.getDNSForwarderInfo(tierID, dnsForwarderClients{
+		config: tier_1s.NewDnsForwarderClient(a.connector),
+		status: t1DNSForwarder.NewStatusClient(a.connector),
+		stats:  t1DNSForwarder.NewStatisticsClient(a.connector),
+	})
+}
+
+// getDNSForwarderInfo - returns nil without error when gateway has no dns forwarder
+func (a *NSXApi) getDNSForwarderInfo(tierID string, clients dnsForwarderClients) (*DNSForwarderInfo, error) {
+	var err error
+
+	a.log.Debugf("fetching dns forwarder of gateway '%s'", tierID)
+	res := DNSForwarderInfo{
+		GatewayID: tierID,
+	}
+
+	res.Config, err = clients.config.Get(tierID)
+	if isNotFound(err) {
+		a.log.Debugf("no dns forwarder found for gateway '%s'", tierID)
+		return nil, nil
+	}
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dns forwarder of gateway '%s'", tierID)
+		return nil, err
+	}
+
+	status, err := clients.status.Get(tierID, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dns forwarder status of gateway '%s'", tierID)
+		return nil, err
+	}
+	res.Status, err = first[model.NsxTDNSForwarderStatus](a, status.StatusPerEnforcementPoint, model.NsxTDNSForwarderStatusBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dns forwarder status of gateway '%s'", tierID)
+		return nil, err
+	}
+
+	stats, err := clients.stats.Get(tierID, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dns forwarder statistics of gateway '%s'", tierID)
+		return nil, err
+	}
+	res.Stats, err = first[model.NsxTDNSForwarderStatistics](a, stats.StatisticsPerEnforcementPoint, model.NsxTDNSForwarderStatisticsBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch dns forwarder statistics of gateway '%s'", tierID)
+		return nil, err
+	}
+
+	return &res, nil
+}
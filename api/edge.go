@@ -0,0 +1,220 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+type EdgeClusterInfo struct {
+	Cluster manager.EdgeCluster
+	Members []EdgeClusterMemberInfo
+}
+
+// EdgeClusterMemberInfo - DeploymentType is the deployment type of member transport node,
+// empty when unknown
+type EdgeClusterMemberInfo struct {
+	Member         manager.EdgeClusterMember
+	Status         manager.TransportNodeStatus
+	DeploymentType string
+}
+
+// EdgeCPUUsage - usage percentage of datapath (dpdk) and service (non dpdk) cores
+type EdgeCPUUsage struct {
+	AvgDatapath     *float64 `json:"avg_cpu_core_usage_dpdk"`
+	AvgService      *float64 `json:"avg_cpu_core_usage_non_dpdk"`
+	HighestDatapath *float64 `json:"highest_cpu_core_usage_dpdk"`
+	HighestService  *float64 `json:"highest_cpu_core_usage_non_dpdk"`
+}
+
+type EdgeMemPoolUsage struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Usage       *float64 `json:"usage"`
+}
+
+type EdgeDatapathMemUsage struct {
+	HeapUsage  *float64           `json:"datapath_heap_usage"`
+	PoolsUsage []EdgeMemPoolUsage `json:"datapath_mem_pools_usage"`
+}
+
+// EdgeMemUsage - memory usage percentages of edge node
+type EdgeMemUsage struct {
+	SystemUsage   *float64              `json:"system_mem_usage"`
+	SwapUsage     *float64              `json:"swap_usage"`
+	CacheUsage    *float64              `json:"cache_usage"`
+	DatapathUsage *float64              `json:"datapath_total_usage"`
+	Datapath      *EdgeDatapathMemUsage `json:"datapath_mem_usage_details"`
+}
+
+// EdgeSystemStatus - vendored manager.NodeStatusProperties misses edge cpu and memory
+// usage details, they are decoded here
+type EdgeSystemStatus struct {
+	manager.NodeStatusProperties
+	CPUUsage *EdgeCPUUsage `json:"cpu_usage,omitempty"`
+	MemUsage *EdgeMemUsage `json:"edge_mem_usage,omitempty"`
+}
+
+// EdgeFlowCacheCoreStats - datapath flow cache statistics of an edge datapath core
+type EdgeFlowCacheCoreStats struct {
+	CoreID    int64    `json:"core_id"`
+	MicroHit  *float64 `json:"micro_hit"`
+	MicroMiss *float64 `json:"micro_miss"`
+	MegaHit   *float64 `json:"mega_hit"`
+	MegaMiss  *float64 `json:"mega_miss"`
+}
+
+// EdgeFlowCacheStats - datapath flow cache statistics of edge node, not covered by sdks
+type EdgeFlowCacheStats struct {
+	Cores []EdgeFlowCacheCoreStats `json:"results"`
+}
+
+type EdgeNodeStatus struct {
+	manager.NodeStatus
+	SystemStatus *EdgeSystemStatus `json:"system_status,omitempty"`
+}
+
+// EdgeNodeInfo - status of an edge transport node, ClusterID and MemberIndex are empty
+// when edge is not member of any edge cluster
+type EdgeNodeInfo struct {
+	Node        manager.TransportNode
+	ClusterID   string
+	MemberIndex string
+	Status      manager.TransportNodeStatus
+	NodeStatus  EdgeNodeStatus
+	FlowCache   *EdgeFlowCacheStats
+}
+
+func (a *NSXApi) ListEdgeClusters() ([]manager.EdgeCluster, error) {
+	a.log.Debugf("fetching edge clusters list")
+	res := []manager.EdgeCluster{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		clusters, _, err := a.client.NetworkTransportApi.ListEdgeClusters(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list edge clusters")
+			return nil, err
+		}
+		res = append(res, clusters.Results...)
+
+		if clusters.Cursor == "" {
+			break
+		}
+		opts["cursor"] = clusters.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) ListEdgeNodes() ([]manager.TransportNode, error) {
+	return a.listTransportNodes("EdgeNode")
+}
+
+func (a *NSXApi) GetEdgeNodeInfo(node manager.TransportNode, clusters []manager.EdgeCluster) (*EdgeNodeInfo, error) {
+	var err error
+
+	info := EdgeNodeInfo{
+		Node: node,
+	}
+	for _, cCluster := range clusters {
+		for _, cMember := range cCluster.Members {
+			if cMember.TransportNodeId == node.Id {
+				info.ClusterID = cCluster.Id
+				info.MemberIndex = fmt.Sprintf("%d", cMember.MemberIndex)
+			}
+		}
+	}
+
+	a.log.Debugf("fetching edge transport node '%s' status", node.Id)
+	// nolint: bodyclose
+	info.Status, _, err = a.client.TroubleshootingAndMonitoringApi.GetTransportNodeStatus(a.client.Context, node.Id, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch edge transport node '%s' status", node.Id)
+		return nil, err
+	}
+
+	err = a.getJSON(fmt.Sprintf("/api/v1/fabric/nodes/%s/status", node.NodeId), nil, &info.NodeStatus)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch edge node '%s' system status", node.NodeId)
+		return nil, err
+	}
+
+	// flow cache statistics are not available on every edge version, ignore them when missing
+	a.log.Debugf("fetching edge transport node '%s' flow cache statistics", node.Id)
+	flowCache := EdgeFlowCacheStats{}
+	err = a.getJSON(fmt.Sprintf("/api/v1/transport-nodes/%s/node/services/dataplane/flow-cache-stats", node.Id), nil, &flowCache)
+	if err != nil && !isNotFound(err) {
+		a.log.WithError(err).Errorf("could not fetch edge transport node '%s' flow cache statistics", node.Id)
+		return nil, err
+	}
+	if err == nil {
+		info.FlowCache = &flowCache
+	}
+
+	return &info, nil
+}
+
+// GetEdgeClusterInfo - deployments are edge deployment types given by ListEdgeDeploymentTypes
+func (a *NSXApi) GetEdgeClusterInfo(
+	cluster manager.EdgeCluster,
+	statuses []manager.TransportNodeStatus,
+	deployments map[string]string,
+) (*EdgeClusterInfo, error) {
+	info := EdgeClusterInfo{
+		Cluster: cluster,
+	}
+	for _, cMember := range cluster.Members {
+		status, err := a.getTransportNodeStatus(cMember.TransportNodeId, statuses)
+		if err != nil {
+			return nil, err
+		}
+		info.Members = append(info.Members, EdgeClusterMemberInfo{
+			Member:         cMember,
+			Status:         status,
+			DeploymentType: deployments[cMember.TransportNodeId],
+		})
+	}
+	return &info, nil
+}
+
+type edgeDeployment struct {
+	ID                 string `json:"id"`
+	NodeDeploymentInfo struct {
+		DeploymentType string `json:"deployment_type"`
+	} `json:"node_deployment_info"`
+}
+
+type edgeDeploymentList struct {
+	Cursor  string           `json:"cursor"`
+	Results []edgeDeployment `json:"results"`
+}
+
+// ListEdgeDeploymentTypes - deployment types of edge transport nodes indexed by transport node
+// id, vendored manager.TransportNode doesn't decode node_deployment_info
+func (a *NSXApi) ListEdgeDeploymentTypes() (map[string]string, error) {
+	a.log.Debugf("fetching edge transport nodes deployment types")
+	res := map[string]string{}
+	query := url.Values{}
+	query.Set("node_types", "EdgeNode")
+
+	for {
+		nodes := edgeDeploymentList{}
+		if err := a.getJSON("/api/v1/transport-nodes", query, &nodes); err != nil {
+			a.log.WithError(err).Errorf("could not list edge transport nodes deployment types")
+			return nil, err
+		}
+		for _, cNode := range nodes.Results {
+			res[cNode.ID] = cNode.NodeDeploymentInfo.DeploymentType
+		}
+
+		if nodes.Cursor == "" {
+			break
+		}
+		query.Set("cursor", nodes.Cursor)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/domains"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/domains/security_policies"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+type SecurityPolicyInfo struct {
+	Domain   model.Domain
+	Config   model.SecurityPolicy
+	Rules    []model.Rule
+	Stats    []model.RuleStatistics
+	MaxRules int
+}
+
+func (a *NSXApi) ListDomains() ([]model.Domain, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching domains list")
+	res := []model.Domain{}
+	cli := infra.NewDomainsClient(a.connector)
+
+	for {
+		domains, err := cli.List(cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list domains")
+			return nil, err
+		}
+
+		for _, cRes := range domains.Results {
+			if match(a.config.DFWDomainFilters, *cRes.DisplayName, *cRes.Id) {
+				log.Debugf("found domain '%s' (%s)", *cRes.DisplayName, *cRes.Id)
+				res = append(res, cRes)
+			}
+		}
+
+		cursor = domains.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) listSecurityPolicies(domainID string) ([]model.SecurityPolicy, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching security policies list of domain '%s'", domainID)
+	res := []model.SecurityPolicy{}
+	cli := domains.NewSecurityPoliciesClient(a.connector)
+	ruleCount := true
+
+	for {
+		policies, err := cli.List(domainID, cursor, &False, &ruleCount, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list security policies of domain '%s'", domainID)
+			return nil, err
+		}
+
+		for _, cRes := range policies.Results {
+			if match(a.config.DFWPolicyFilters, *cRes.DisplayName, *cRes.Id) {
+				log.Debugf("found security policy '%s' (%s)", *cRes.DisplayName, *cRes.Id)
+				res = append(res, cRes)
+			}
+		}
+
+		cursor = policies.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) listSecurityPolicyRules(domainID string, policyID string) ([]model.Rule, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching rules of security policy '%s'", policyID)
+	res := []model.Rule{}
+	cli := security_policies.NewRulesClient(a.connector)
+
+	for {
+		rules, err := cli.List(domainID, policyID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list rules of security policy '%s'", policyID)
+			return nil, err
+		}
+		res = append(res, rules.Results...)
+
+		cursor = rules.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getSecurityPolicyStats(domainID string, policyID string) ([]model.RuleStatistics, error) {
+	a.log.Debugf("fetching statistics of security policy '%s'", policyID)
+
+	cli := security_policies.NewStatisticsClient(a.connector)
+	stats, err := cli.List(domainID, policyID, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch security policy '%s' statistics", policyID)
+		return nil, err
+	}
+
+	// statistics are given per enforcement point, sum them by rule
+	res := []model.RuleStatistics{}
+	index := map[string]int{}
+	for _, cRes := range stats.Results {
+		if cRes.Statistics == nil {
+			continue
+		}
+		for _, cStat := range cRes.Statistics.Results {
+			rule := ""
+			if cStat.Rule != nil {
+				rule = *cStat.Rule
+			}
+			idx, ok := index[rule]
+			if !ok {
+				index[rule] = len(res)
+				res = append(res, cStat)
+				continue
+			}
+			sum(&res[idx].HitCount, cStat.HitCount)
+			sum(&res[idx].PacketCount, cStat.PacketCount)
+			sum(&res[idx].ByteCount, cStat.ByteCount)
+			sum(&res[idx].SessionCount, cStat.SessionCount)
+		}
+	}
+	return res, nil
+}
+
+func sum(total **int64, value *int64) {
+	if value == nil {
+		return
+	}
+	if *total == nil {
+		v := *value
+		*total = &v
+		return
+	}
+	v := **total + *value
+	*total = &v
+}
+
+func (a *NSXApi) GetSecurityPolicyInfos(domain model.Domain) ([]SecurityPolicyInfo, error) {
+	domainID := *domain.Id
+	policies, err := a.listSecurityPolicies(domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []SecurityPolicyInfo{}
+	for _, cPolicy := range policies {
+		policyID := *cPolicy.Id
+		rules, err := a.listSecurityPolicyRules(domainID, policyID)
+		if err != nil {
+			return nil, err
+		}
+		stats, err := a.getSecurityPolicyStats(domainID, policyID)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, SecurityPolicyInfo{
+			Domain:   domain,
+			Config:   cPolicy,
+			Rules:    rules,
+			Stats:    stats,
+			MaxRules: a.config.DFWMaxRulesPerPolicy,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,536 @@
+package api
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+
+	"github.com/vmware/go-vmware-nsxt/manager"
+	vapiData_ "github.com/vmware/vsphere-automation-sdk-go/runtime/data"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/ip_blocks"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/ip_pools"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+type IPPoolInfo struct {
+	ID      string
+	Name    string
+	Usage   *model.PolicyPoolUsage
+	Subnets []IPPoolSubnetInfo
+}
+
+// IPPoolSubnetInfo - address counts of a pool subnet, computed from allocation ranges
+// and pool allocations
+type IPPoolSubnetInfo struct {
+	CIDR      string
+	Total     float64
+	Allocated float64
+}
+
+type IPBlockInfo struct {
+	ID        string
+	Name      string
+	CIDR      string
+	Size      float64
+	Allocated float64
+}
+
+// GetIPPoolInfos - fetches address counts of policy ip pools, falls back to legacy manager
+// ip pools when policy api gives nothing
+func (a *NSXApi) GetIPPoolInfos() ([]IPPoolInfo, error) {
+	pools, err := a.listIPPools()
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		a.log.Debugf("no policy ip pool found, falling back to manager api")
+		return a.getLegacyIPPoolInfos()
+	}
+
+	res := []IPPoolInfo{}
+	for _, cPool := range pools {
+		info, err := a.getIPPoolInfo(cPool)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *info)
+	}
+	return res, nil
+}
+
+func (a *NSXApi) listIPPools() ([]model.IpAddressPool, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching ip pools list")
+	res := []model.IpAddressPool{}
+	cli := infra.NewIpPoolsClient(a.connector)
+
+	for {
+		pools, err := cli.List(cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list ip pools")
+			return nil, err
+		}
+		res = append(res, pools.Results...)
+
+		cursor = pools.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getIPPoolInfo(pool model.IpAddressPool) (*IPPoolInfo, error) {
+	allocations, err := a.listIPPoolAllocations(*pool.Id)
+	if err != nil {
+		return nil, err
+	}
+	subnets, err := a.listIPPoolSubnets(*pool.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	info := IPPoolInfo{
+		ID:    *pool.Id,
+		Name:  *pool.DisplayName,
+		Usage: pool.PoolUsage,
+	}
+	for _, cSubnet := range subnets {
+		subnet := IPPoolSubnetInfo{
+			CIDR: cSubnet.cidr,
+		}
+		for _, cRange := range cSubnet.ranges {
+			subnet.Total += rangeSize(cRange[0], cRange[1])
+			subnet.Allocated += countInRange(allocations, cRange[0], cRange[1])
+		}
+		info.Subnets = append(info.Subnets, subnet)
+	}
+	return &info, nil
+}
+
+func (a *NSXApi) listIPPoolAllocations(poolID string) ([]netip.Addr, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching allocations of ip pool '%s'", poolID)
+	res := []netip.Addr{}
+	cli := ip_pools.NewIpAllocationsClient(a.connector)
+
+	for {
+		allocations, err := cli.List(poolID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list allocations of ip pool '%s'", poolID)
+			return nil, err
+		}
+		for _, cAlloc := range allocations.Results {
+			if cAlloc.AllocationIp == nil {
+				continue
+			}
+			addr, err := netip.ParseAddr(*cAlloc.AllocationIp)
+			if err == nil {
+				res = append(res, addr)
+			}
+		}
+
+		cursor = allocations.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// ipPoolSubnet - cidr and allocation ranges of a static or block pool subnet
+type ipPoolSubnet struct {
+	cidr   string
+	ranges [][2]netip.Addr
+}
+
+func (a *NSXApi) listIPPoolSubnets(poolID string) ([]ipPoolSubnet, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching subnets of ip pool '%s'", poolID)
+	res := []ipPoolSubnet{}
+	cli := ip_pools.NewIpSubnetsClient(a.connector)
+
+	for {
+		subnets, err := cli.List(poolID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list subnets of ip pool '%s'", poolID)
+			return nil, err
+		}
+		for _, cValue := range subnets.Results {
+			subnet, err := a.readIPPoolSubnet(poolID, cValue)
+			if err != nil {
+				return nil, err
+			}
+			if subnet != nil {
+				res = append(res, *subnet)
+			}
+		}
+
+		cursor = subnets.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) readIPPoolSubnet(poolID string, value *vapiData_.StructValue) (*ipPoolSubnet, error) {
+	base, err := convert[model.IpAddressPoolSubnet](a, value, model.IpAddressPoolSubnetBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not read subnet of ip pool '%s'", poolID)
+		return nil, err
+	}
+
+	switch base.ResourceType {
+	case model.IpAddressPoolSubnet_RESOURCE_TYPE_IPADDRESSPOOLSTATICSUBNET:
+		s, err := convert[model.IpAddressPoolStaticSubnet](a, value, model.IpAddressPoolStaticSubnetBindingType)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not read static subnet of ip pool '%s'", poolID)
+			return nil, err
+		}
+		res := ipPoolSubnet{}
+		if s.Cidr != nil {
+			res.cidr = *s.Cidr
+		}
+		for _, cRange := range s.AllocationRanges {
+			if cRange.Start == nil || cRange.End == nil {
+				continue
+			}
+			start, errStart := netip.ParseAddr(*cRange.Start)
+			end, errEnd := netip.ParseAddr(*cRange.End)
+			if errStart != nil || errEnd != nil {
+				a.log.Warnf("invalid allocation range '%s-%s' in ip pool '%s'", *cRange.Start, *cRange.End, poolID)
+				continue
+			}
+			res.ranges = append(res.ranges, [2]netip.Addr{start, end})
+		}
+		return &res, nil
+	case model.IpAddressPoolSubnet_RESOURCE_TYPE_IPADDRESSPOOLBLOCKSUBNET:
+		s, err := convert[model.IpAddressPoolBlockSubnet](a, value, model.IpAddressPoolBlockSubnetBindingType)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not read block subnet of ip pool '%s'", poolID)
+			return nil, err
+		}
+		if s.Cidr == nil {
+			return nil, nil
+		}
+		prefix, err := netip.ParsePrefix(*s.Cidr)
+		if err != nil {
+			a.log.Warnf("invalid cidr '%s' in ip pool '%s'", *s.Cidr, poolID)
+			return nil, nil
+		}
+		start, end := prefixRange(prefix)
+		return &ipPoolSubnet{
+			cidr:   *s.Cidr,
+			ranges: [][2]netip.Addr{{start, end}},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (a *NSXApi) getLegacyIPPoolInfos() ([]IPPoolInfo, error) {
+	a.log.Debugf("fetching manager ip pools list")
+	res := []IPPoolInfo{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		pools, _, err := a.client.PoolManagementApi.ListIpPools(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list manager ip pools")
+			return nil, err
+		}
+
+		for _, cPool := range pools.Results {
+			info, err := a.getLegacyIPPoolInfo(cPool)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, *info)
+		}
+
+		if pools.Cursor == "" {
+			break
+		}
+		opts["cursor"] = pools.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getLegacyIPPoolInfo(pool manager.IpPool) (*IPPoolInfo, error) {
+	a.log.Debugf("fetching allocations of manager ip pool '%s'", pool.Id)
+
+	// nolint: bodyclose
+	allocations, _, err := a.client.PoolManagementApi.ListIpPoolAllocations(a.client.Context, pool.Id)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not list allocations of manager ip pool '%s'", pool.Id)
+		return nil, err
+	}
+	addrs := []netip.Addr{}
+	for _, cAlloc := range allocations.Results {
+		addr, err := netip.ParseAddr(cAlloc.AllocationId)
+		if err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	info := IPPoolInfo{
+		ID:   pool.Id,
+		Name: pool.DisplayName,
+	}
+	if pool.PoolUsage != nil {
+		info.Usage = &model.PolicyPoolUsage{
+			AllocatedIpAllocations: &pool.PoolUsage.AllocatedIds,
+			AvailableIps:           &pool.PoolUsage.FreeIds,
+			TotalIps:               &pool.PoolUsage.TotalIds,
+		}
+	}
+	for _, cSubnet := range pool.Subnets {
+		subnet := IPPoolSubnetInfo{
+			CIDR: cSubnet.Cidr,
+		}
+		for _, cRange := range cSubnet.AllocationRanges {
+			start, errStart := netip.ParseAddr(cRange.Start)
+			end, errEnd := netip.ParseAddr(cRange.End)
+			if errStart != nil || errEnd != nil {
+				a.log.Warnf("invalid allocation range '%s-%s' in manager ip pool '%s'", cRange.Start, cRange.End, pool.Id)
+				continue
+			}
+			subnet.Total += rangeSize(start, end)
+			subnet.Allocated += countInRange(addrs, start, end)
+		}
+		info.Subnets = append(info.Subnets, subnet)
+	}
+	return &info, nil
+}
+
+// GetIPBlockInfos - fetches allocation of policy ip blocks, falls back to legacy manager
+// ip blocks when policy api gives nothing
+func (a *NSXApi) GetIPBlockInfos() ([]IPBlockInfo, error) {
+	blocks, err := a.listIPBlocks()
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		a.log.Debugf("no policy ip block found, falling back to manager api")
+		return a.getLegacyIPBlockInfos()
+	}
+
+	res := []IPBlockInfo{}
+	for _, cBlock := range blocks {
+		if cBlock.Cidr == nil {
+			continue
+		}
+		info, err := a.getIPBlockInfo(*cBlock.Id, *cBlock.DisplayName, *cBlock.Cidr)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *info)
+	}
+	return res, nil
+}
+
+func (a *NSXApi) listIPBlocks() ([]model.IpAddressBlock, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching ip blocks list")
+	res := []model.IpAddressBlock{}
+	cli := infra.NewIpBlocksClient(a.connector)
+
+	for {
+		blocks, err := cli.List(cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list ip blocks")
+			return nil, err
+		}
+		res = append(res, blocks.Results...)
+
+		cursor = blocks.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getIPBlockInfo(blockID string, name string, cidr string) (*IPBlockInfo, error) {
+	a.log.Debugf("fetching usage of ip block '%s'", blockID)
+
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		a.log.WithError(err).Errorf("invalid cidr '%s' of ip block '%s'", cidr, blockID)
+		return nil, err
+	}
+
+	cli := ip_blocks.NewUsageClient(a.connector)
+	usage, err := cli.Get(blockID)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch usage of ip block '%s'", blockID)
+		return nil, err
+	}
+
+	info := IPBlockInfo{
+		ID:   blockID,
+		Name: name,
+		CIDR: cidr,
+		Size: prefixSize(prefix),
+	}
+	for _, cRange := range usage.UsedIpRanges {
+		size, err := parseRangeSize(cRange)
+		if err != nil {
+			a.log.WithError(err).Warnf("invalid used range '%s' in ip block '%s'", cRange, blockID)
+			continue
+		}
+		info.Allocated += size
+	}
+	return &info, nil
+}
+
+func (a *NSXApi) getLegacyIPBlockInfos() ([]IPBlockInfo, error) {
+	a.log.Debugf("fetching manager ip blocks list")
+	res := []IPBlockInfo{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		blocks, _, err := a.client.PoolManagementApi.ListIpBlocks(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list manager ip blocks")
+			return nil, err
+		}
+
+		for _, cBlock := range blocks.Results {
+			info, err := a.getLegacyIPBlockInfo(cBlock)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, *info)
+		}
+
+		if blocks.Cursor == "" {
+			break
+		}
+		opts["cursor"] = blocks.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getLegacyIPBlockInfo(block manager.IpBlock) (*IPBlockInfo, error) {
+	a.log.Debugf("fetching subnets of manager ip block '%s'", block.Id)
+
+	prefix, err := netip.ParsePrefix(block.Cidr)
+	if err != nil {
+		a.log.WithError(err).Errorf("invalid cidr '%s' of manager ip block '%s'", block.Cidr, block.Id)
+		return nil, err
+	}
+
+	info := IPBlockInfo{
+		ID:   block.Id,
+		Name: block.DisplayName,
+		CIDR: block.Cidr,
+		Size: prefixSize(prefix),
+	}
+	opts := map[string]interface{}{
+		"blockId": block.Id,
+	}
+
+	for {
+		// nolint: bodyclose
+		subnets, _, err := a.client.PoolManagementApi.ListIpBlockSubnets(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list subnets of manager ip block '%s'", block.Id)
+			return nil, err
+		}
+		for _, cSubnet := range subnets.Results {
+			info.Allocated += float64(cSubnet.Size)
+		}
+
+		if subnets.Cursor == "" {
+			break
+		}
+		opts["cursor"] = subnets.Cursor
+	}
+
+	return &info, nil
+}
+
+// countInRange - number of given addresses between start and end, both included
+func countInRange(addrs []netip.Addr, start netip.Addr, end netip.Addr) float64 {
+	res := 0.0
+	for _, cAddr := range addrs {
+		if start.Compare(cAddr) <= 0 && cAddr.Compare(end) <= 0 {
+			res++
+		}
+	}
+	return res
+}
+
+// parseRangeSize - number of addresses in a 'start-end' range, a cidr or a single address
+func parseRangeSize(value string) (float64, error) {
+	if bounds := strings.SplitN(value, "-", 2); len(bounds) == 2 {
+		start, errStart := netip.ParseAddr(strings.TrimSpace(bounds[0]))
+		end, errEnd := netip.ParseAddr(strings.TrimSpace(bounds[1]))
+		if errStart != nil || errEnd != nil {
+			return 0, fmt.Errorf("invalid range '%s'", value)
+		}
+		return rangeSize(start, end), nil
+	}
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		return prefixSize(prefix), nil
+	}
+	if _, err := netip.ParseAddr(value); err == nil {
+		return 1, nil
+	}
+	return 0, fmt.Errorf("invalid range '%s'", value)
+}
+
+// rangeSize - number of addresses between start and end, both included, 0 when end is
+// before start
+func rangeSize(start netip.Addr, end netip.Addr) float64 {
+	if end.Less(start) {
+		return 0
+	}
+	s := start.As16()
+	e := end.As16()
+	size := new(big.Int).Sub(new(big.Int).SetBytes(e[:]), new(big.Int).SetBytes(s[:]))
+	res, _ := new(big.Float).SetInt(size.Add(size, big.NewInt(1))).Float64()
+	return res
+}
+
+// prefixSize - number of addresses in given cidr
+func prefixSize(prefix netip.Prefix) float64 {
+	bits := prefix.Addr().BitLen() - prefix.Bits()
+	res, _ := new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), uint(bits))).Float64()
+	return res
+}
+
+// prefixRange - first and last addresses of given cidr
+func prefixRange(prefix netip.Prefix) (netip.Addr, netip.Addr) {
+	start := prefix.Masked().Addr()
+	bytes := start.AsSlice()
+	bits := prefix.Bits()
+	for idx := range bytes {
+		switch {
+		case bits >= 8:
+			bits -= 8
+		case bits > 0:
+			bytes[idx] |= byte(0xff >> bits)
+			bits = 0
+		default:
+			bytes[idx] = 0xff
+		}
+	}
+	end, _ := netip.AddrFromSlice(bytes)
+	return start, end
+}
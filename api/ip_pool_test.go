@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestPrefixRange(t *testing.T) {
+	tests := []struct {
+		prefix string
+		start  string
+		end    string
+	}{
+		{"10.0.0.0/24", "10.0.0.0", "10.0.0.255"},
+		{"10.0.0.17/28", "10.0.0.16", "10.0.0.31"},
+		{"10.0.0.1/32", "10.0.0.1", "10.0.0.1"},
+		{"0.0.0.0/0", "0.0.0.0", "255.255.255.255"},
+		{"2001:db8::/64", "2001:db8::", "2001:db8::ffff:ffff:ffff:ffff"},
+		{"2001:db8::1/128", "2001:db8::1", "2001:db8::1"},
+		{"::/0", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+	for _, tt := range tests {
+		start, end := prefixRange(netip.MustParsePrefix(tt.prefix))
+		if start.String() != tt.start || end.String() != tt.end {
+			t.Errorf("prefixRange(%s) = %s-%s, want %s-%s", tt.prefix, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestRangeSize(t *testing.T) {
+	tests := []struct {
+		start string
+		end   string
+		size  float64
+	}{
+		{"10.0.0.1", "10.0.0.1", 1},
+		{"10.0.0.1", "10.0.0.10", 10},
+		{"10.0.0.0", "10.0.1.255", 512},
+		{"0.0.0.0", "255.255.255.255", 1 << 32},
+		{"10.0.0.10", "10.0.0.1", 0},
+		{"2001:db8::1", "2001:db8::ff", 255},
+		{"2001:db8::ff", "2001:db8::1", 0},
+	}
+	for _, tt := range tests {
+		size := rangeSize(netip.MustParseAddr(tt.start), netip.MustParseAddr(tt.end))
+		if size != tt.size {
+			t.Errorf("rangeSize(%s, %s) = %v, want %v", tt.start, tt.end, size, tt.size)
+		}
+	}
+}
+
+func TestPrefixSize(t *testing.T) {
+	tests := []struct {
+		prefix string
+		size   float64
+	}{
+		{"10.0.0.0/24", 256},
+		{"10.0.0.1/32", 1},
+		{"0.0.0.0/0", 1 << 32},
+		{"2001:db8::/120", 256},
+		{"2001:db8::1/128", 1},
+		{"::/0", 3.402823669209385e+38},
+	}
+	for _, tt := range tests {
+		size := prefixSize(netip.MustParsePrefix(tt.prefix))
+		if size != tt.size {
+			t.Errorf("prefixSize(%s) = %v, want %v", tt.prefix, size, tt.size)
+		}
+	}
+}
+
+func TestParseRangeSize(t *testing.T) {
+	tests := []struct {
+		value string
+		size  float64
+		err   bool
+	}{
+		{"10.0.0.1-10.0.0.10", 10, false},
+		{"10.0.0.1 - 10.0.0.10", 10, false},
+		{"10.0.0.10-10.0.0.1", 0, false},
+		{"10.0.0.0/30", 4, false},
+		{"10.0.0.1", 1, false},
+		{"2001:db8::1-2001:db8::10", 16, false},
+		{"2001:db8::/126", 4, false},
+		{"10.0.0.1-foo", 0, true},
+		{"foo", 0, true},
+	}
+	for _, tt := range tests {
+		size, err := parseRangeSize(tt.value)
+		if (err != nil) != tt.err {
+			t.Errorf("parseRangeSize(%s) error = %v, want error %v", tt.value, err, tt.err)
+			continue
+		}
+		if size != tt.size {
+			t.Errorf("parseRangeSize(%s) = %v, want %v", tt.value, size, tt.size)
+		}
+	}
+}
+
+func TestCountInRange(t *testing.T) {
+	addrs := []netip.Addr{
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("10.0.0.5"),
+		netip.MustParseAddr("10.0.0.10"),
+		netip.MustParseAddr("10.0.1.1"),
+		netip.MustParseAddr("2001:db8::1"),
+		netip.MustParseAddr("2001:db8::ff"),
+	}
+	tests := []struct {
+		start string
+		end   string
+		count float64
+	}{
+		{"10.0.0.1", "10.0.0.10", 3},
+		{"10.0.0.2", "10.0.0.9", 1},
+		{"10.0.0.5", "10.0.0.5", 1},
+		{"10.0.0.0", "10.0.0.255", 3},
+		{"0.0.0.0", "255.255.255.255", 4},
+		{"10.0.0.10", "10.0.0.1", 0},
+		{"2001:db8::", "2001:db8::ffff", 2},
+		{"::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", 2},
+		{"2001:db8::ff", "2001:db8::1", 0},
+	}
+	for _, tt := range tests {
+		count := countInRange(addrs, netip.MustParseAddr(tt.start), netip.MustParseAddr(tt.end))
+		if count != tt.count {
+			t.Errorf("countInRange(%s, %s) = %v, want %v", tt.start, tt.end, count, tt.count)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s"
+	t0IPSec "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/ipsec_vpn_services"
+	t0IPSecSessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/ipsec_vpn_services/sessions"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s"
+	t1IPSec "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/ipsec_vpn_services"
+	t1IPSecSessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/ipsec_vpn_services/sessions"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+type IPSecVpnSessionInfo struct {
+	GatewayID string
+	Service   model.IPSecVpnService
+	Config    *model.IPSecVpnSession
+	Status    *model.IPSecVpnSessionStatusNsxt
+	Stats     *model.IPSecVpnSessionStatisticsNsxt
+}
+
+// ipsecVpnClients - tier0 and tier1 ipsec clients share the same signatures, gather them
+// to implement the logic only once
+type ipsecVpnClients struct {
+	services interface {
+		List(string, *string, *bool, *string, *int64, *bool, *string) (model.IPSecVpnServiceListResult, error)
+	}
+	sessions interface {
+		List(string, string, *string, *bool, *string, *int64, *bool, *string) (model.IPSecVpnSessionListResult, error)
+	}
+	status interface {
+		Get(string, string, string, *string, *string) (model.AggregateIPSecVpnSessionStatus, error)
+	}
+	stats interface {
+		Get(string, string, string, *string, *string) (model.AggregateIPSecVpnSessionStatistics, error)
+	}
+}
+
+func (a *NSXApi) GetT0IPSecVpnSessionInfos(tierID string) ([]IPSecVpnSessionInfo, error) {
+	return a.getIPSecVpnSessionInfos(tierID, ipsecVpnClients{
+		services: tier_0s.NewIpsecVpnServicesClient(a.connector),
+		sessions: t0IPSec.NewSessionsClient(a.connector),
+		status:   t0IPSecSessions.NewDetailedStatusClient(a.connector),
+		stats:    t0IPSecSessions.NewStatisticsClient(a.connector),
+	})
+}
+
+func (a *NSXApi) GetT1IPSecVpnSessionInfos(tierID string) ([]IPSecVpnSessionInfo, error) {
+	return a.getIPSecVpnSessionInfos(tierID, ipsecVpnClients{
+		services: tier_1s.NewIpsecVpnServicesClient(a.connector),
+		sessions: t1IPSec.NewSessionsClient(a.connector),
+		status:   t1IPSecSessions.NewDetailedStatusClient(a.connector),
+		stats:    t1IPSecSessions.NewStatisticsClient(a.connector),
+	})
+}
+
+func (a *NSXApi) getIPSecVpnSessionInfos(tierID string, clients ipsecVpnClients) ([]IPSecVpnSessionInfo, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching ipsec vpn services of gateway '%s'", tierID)
+	res := []IPSecVpnSessionInfo{}
+
+	for {
+		services, err := clients.services.List(tierID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list ipsec vpn services of gateway '%s'", tierID)
+			return nil, err
+		}
+
+		for _, cService := range services.Results {
+			sessions, err := a.listIPSecVpnSessions(tierID, *cService.Id, clients)
+			if err != nil {
+				return nil, err
+			}
+			for _, cSession := range sessions {
+				info := IPSecVpnSessionInfo{
+					GatewayID: tierID,
+					Service:   cService,
+					Config:    cSession,
+				}
+				info.Status, err = a.getIPSecVpnSessionStatus(tierID, *cService.Id, *cSession.Id, clients)
+				if err != nil {
+					return nil, err
+				}
+				info.Stats, err = a.getIPSecVpnSessionStats(tierID, *cService.Id, *cSession.Id, clients)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, info)
+			}
+		}
+
+		cursor = services.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) listIPSecVpnSessions(tierID string, serviceID string, clients ipsecVpnClients) ([]*model.IPSecVpnSession, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching sessions of ipsec vpn service '%s'", serviceID)
+	res := []*model.IPSecVpnSession{}
+
+	for {
+		sessions, err := clients.sessions.List(tierID, serviceID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list sessions of ipsec vpn service '%s'", serviceID)
+			return nil, err
+		}
+
+		for _, cRes := range sessions.Results {
+			session, err := convert[model.IPSecVpnSession](a, cRes, model.IPSecVpnSessionBindingType)
+			if err != nil {
+				a.log.WithError(err).Errorf("could not read session of ipsec vpn service '%s'", serviceID)
+				return nil, err
+			}
+			res = append(res, session)
+		}
+
+		cursor = sessions.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getIPSecVpnSessionStatus(tierID string, serviceID string, sessionID string, clients ipsecVpnClients) (*model.IPSecVpnSessionStatusNsxt, error) {
+	a.log.Debugf("fetching status of ipsec vpn session '%s'", sessionID)
+
+	statuses, err := clients.status.Get(tierID, serviceID, sessionID, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch ipsec vpn session '%s' status", sessionID)
+		return nil, err
+	}
+
+	res, err := first[model.IPSecVpnSessionStatusNsxt](a, statuses.Results, model.IPSecVpnSessionStatusNsxtBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch ipsec vpn session '%s' status", sessionID)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *NSXApi) getIPSecVpnSessionStats(tierID string, serviceID string, sessionID string, clients ipsecVpnClients) (*model.IPSecVpnSessionStatisticsNsxt, error) {
+	a.log.Debugf("fetching statistics of ipsec vpn session '%s'", sessionID)
+
+	stats, err := clients.stats.Get(tierID, serviceID, sessionID, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch ipsec vpn session '%s' statistics", sessionID)
+		return nil, err
+	}
+
+	res, err := first[model.IPSecVpnSessionStatisticsNsxt](a, stats.Results, model.IPSecVpnSessionStatisticsNsxtBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch ipsec vpn session '%s' statistics", sessionID)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s"
+	t0L2Vpn "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/l2vpn_services"
+	t0L2VpnSessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_0s/l2vpn_services/sessions"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s"
+	t1L2Vpn "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/l2vpn_services"
+	t1L2VpnSessions "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/l2vpn_services/sessions"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+type L2VpnSessionInfo struct {
+	GatewayID string
+	Config    model.L2VPNSession
+	Status    *model.L2VPNSessionStatusNsxt
+	Tunnels   []model.IPSecVpnTransportStatus
+	Stats     *model.L2VPNSessionStatisticsNsxt
+}
+
+// l2vpnClients - tier0 and tier1 l2vpn clients share the same signatures, gather them
+// to implement the logic only once
+type l2vpnClients struct {
+	services interface {
+		List(string, *string, *bool, *string, *int64, *bool, *string) (model.L2VPNServiceListResult, error)
+	}
+	sessions interface {
+		List(string, string, *string, *bool, *string, *int64, *bool, *string) (model.L2VPNSessionListResult, error)
+	}
+	status interface {
+		Get(string, string, string, *string, *string) (model.AggregateL2VPNSessionStatus, error)
+	}
+	stats interface {
+		Get(string, string, string, *string, *string) (model.AggregateL2VPNSessionStatistics, error)
+	}
+}
+
+func (a *NSXApi) GetT0L2VpnSessionInfos(tierID string) ([]L2VpnSessionInfo, error) {
+	return a.getL2VpnSessionInfos(tierID, l2vpnClients{
+		services: tier_0s.NewL2vpnServicesClient(a.connector),
+		sessions: t0L2Vpn.NewSessionsClient(a.connector),
+		status:   t0L2VpnSessions.NewDetailedStatusClient(a.connector),
+		stats:    t0L2VpnSessions.NewStatisticsClient(a.connector),
+	})
+}
+
+func (a *NSXApi) GetT1L2VpnSessionInfos(tierID string) ([]L2VpnSessionInfo, error) {
+	return a.getL2VpnSessionInfos(tierID, l2vpnClients{
+		services: tier_1s.NewL2vpnServicesClient(a.connector),
+		sessions: t1L2Vpn.NewSessionsClient(a.connector),
+		status:   t1L2VpnSessions.NewDetailedStatusClient(a.connector),
+		stats:    t1L2VpnSessions.NewStatisticsClient(a.connector),
+	})
+}
+
+func (a *NSXApi) getL2VpnSessionInfos(tierID string, clients l2vpnClients) ([]L2VpnSessionInfo, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching l2vpn services of gateway '%s'", tierID)
+	res := []L2VpnSessionInfo{}
+
+	for {
+		services, err := clients.services.List(tierID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list l2vpn services of gateway '%s'", tierID)
+			return nil, err
+		}
+
+		for _, cService := range services.Results {
+			sessions, err := a.listL2VpnSessions(tierID, *cService.Id, clients)
+			if err != nil {
+				return nil, err
+			}
+			for _, cSession := range sessions {
+				info := L2VpnSessionInfo{
+					GatewayID: tierID,
+					Config:    cSession,
+				}
+				info.Status, info.Tunnels, err = a.getL2VpnSessionStatus(tierID, *cService.Id, *cSession.Id, clients)
+				if err != nil {
+					return nil, err
+				}
+				info.Stats, err = a.getL2VpnSessionStats(tierID, *cService.Id, *cSession.Id, clients)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, info)
+			}
+		}
+
+		cursor = services.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) listL2VpnSessions(tierID string, serviceID string, clients l2vpnClients) ([]model.L2VPNSession, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching sessions of l2vpn service '%s'", serviceID)
+	res := []model.L2VPNSession{}
+
+	for {
+		sessions, err := clients.sessions.List(tierID, serviceID, cursor, &False, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list sessions of l2vpn service '%s'", serviceID)
+			return nil, err
+		}
+		res = append(res, sessions.Results...)
+
+		cursor = sessions.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) getL2VpnSessionStatus(tierID string, serviceID string, sessionID string, clients l2vpnClients) (*model.L2VPNSessionStatusNsxt, []model.IPSecVpnTransportStatus, error) {
+	a.log.Debugf("fetching status of l2vpn session '%s'", sessionID)
+
+	statuses, err := clients.status.Get(tierID, serviceID, sessionID, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch l2vpn session '%s' status", sessionID)
+		return nil, nil, err
+	}
+
+	status, err := first[model.L2VPNSessionStatusNsxt](a, statuses.Results, model.L2VPNSessionStatusNsxtBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch l2vpn session '%s' status", sessionID)
+		return nil, nil, err
+	}
+
+	tunnels := []model.IPSecVpnTransportStatus{}
+	for _, cTunnel := range status.TransportTunnels {
+		tunnel, err := convert[model.IPSecVpnTransportStatus](a, cTunnel, model.IPSecVpnTransportStatusBindingType)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not read l2vpn session '%s' transport tunnel status", sessionID)
+			return nil, nil, err
+		}
+		tunnels = append(tunnels, *tunnel)
+	}
+	return status, tunnels, nil
+}
+
+func (a *NSXApi) getL2VpnSessionStats(tierID string, serviceID string, sessionID string, clients l2vpnClients) (*model.L2VPNSessionStatisticsNsxt, error) {
+	a.log.Debugf("fetching statistics of l2vpn session '%s'", sessionID)
+
+	stats, err := clients.stats.Get(tierID, serviceID, sessionID, nil, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch l2vpn session '%s' statistics", sessionID)
+		return nil, err
+	}
+
+	res, err := first[model.L2VPNSessionStatisticsNsxt](a, stats.Results, model.L2VPNSessionStatisticsNsxtBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch l2vpn session '%s' statistics", sessionID)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/licensing"
+)
+
+type LicenseInfo struct {
+	Licenses []licensing.License
+	Usage    []licensing.FeatureUsage
+}
+
+func (a *NSXApi) GetLicenseInfo() (*LicenseInfo, error) {
+	a.log.Debugf("fetching licenses list")
+	// nolint: bodyclose
+	licenses, _, err := a.client.LicensingApi.GetLicenses(a.client.Context)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not list licenses")
+		return nil, err
+	}
+
+	a.log.Debugf("fetching license usage report")
+	// nolint: bodyclose
+	usage, _, err := a.client.LicensingApi.GetLicenseUsageReport(a.client.Context)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch license usage report")
+		return nil, err
+	}
+
+	return &LicenseInfo{
+		Licenses: licenses.Results,
+		Usage:    usage.FeatureUsageInfo,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
@@ -11,28 +13,45 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+// LBInfo - Incomplete is set when some objects referenced by virtual servers or pools could
+// not be fetched and were skipped
 type LBInfo struct {
 	Config         *model.LBService
 	Status         *model.LBServiceStatus
 	Stats          *model.LBServiceStatistics
+	Usage          *model.LBServiceUsage
 	VirtualServers []VSInfo
 	Pools          []PoolInfo
+	Incomplete     bool
 }
 
 type VSInfo struct {
-	Config *model.LBVirtualServer
-	Status model.LBVirtualServerStatus
-	Stats  model.LBVirtualServerStatistics
+	Config       *model.LBVirtualServer
+	Status       model.LBVirtualServerStatus
+	Stats        model.LBVirtualServerStatistics
+	Certificates []VSCertificateInfo
+}
+
+// VSCertificateInfo - certificate bound to virtual server client ssl profile, Type is
+// either "default" or "sni", Names holds subject common name and dns subject alternative
+// names of leaf certificate
+type VSCertificateInfo struct {
+	Type        string
+	Names       []string
+	Certificate model.TlsCertificate
 }
 
 type PoolInfo struct {
-	Config  *model.LBPool
-	Status  model.LBPoolStatus
-	Stats   model.LBPoolStatistics
-	Members []MemberInfo
+	Config   *model.LBPool
+	Status   model.LBPoolStatus
+	Stats    model.LBPoolStatistics
+	Members  []MemberInfo
+	Monitors []MonitorInfo
 }
 
+// MemberInfo - Config is nil when member does not come from static pool members (ie: group members)
 type MemberInfo struct {
+	Config *model.LBPoolMember
 	Status model.LBPoolMemberStatus
 	Stats  model.LBPoolMemberStatistics
 }
@@ -142,6 +161,63 @@ func (a *NSXApi) getLBServiceStats(lbID string) (*model.LBServiceStatistics, err
 	return &val, nil
 }
 
+func (a *NSXApi) getLBServiceUsage(lbID string) (*model.LBServiceUsage, error) {
+	a.log.Debugf("fetching usage of LBService '%s'", lbID)
+
+	cli := lb_services.NewServiceUsageClient(a.connector)
+	usage, err := cli.Get(lbID, nil, &RealTime)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch LB '%s' usage", lbID)
+		return nil, err
+	}
+
+	res, err := first[model.LBServiceUsage](a, usage.Results, model.LBServiceUsageBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch LB '%s' usage", lbID)
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetLBServiceUsageSummary - fetches capacity usage of all load balancer services
+func (a *NSXApi) GetLBServiceUsageSummary() (*model.LBServiceUsageSummary, error) {
+	a.log.Debugf("fetching LB service usage summary")
+
+	cli := infra.NewLbServiceUsageSummaryClient(a.connector)
+	summary, err := cli.Get(&False)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch LB service usage summary")
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// ListLBNodeUsages - fetches load balancer capacity usage of edge nodes, usages of all nodes
+// are included in the summary so infra.LbNodeUsageClient is not queried node by node
+func (a *NSXApi) ListLBNodeUsages() ([]model.LBEdgeNodeUsage, error) {
+	a.log.Debugf("fetching LB node usage summary")
+
+	cli := infra.NewLbNodeUsageSummaryClient(a.connector)
+	summary, err := cli.Get(nil, &True)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch LB node usage summary")
+		return nil, err
+	}
+
+	res := []model.LBEdgeNodeUsage{}
+	for _, cSummary := range summary.Results {
+		for _, cUsage := range cSummary.NodeUsages {
+			usage, err := convert[model.LBEdgeNodeUsage](a, cUsage, model.LBEdgeNodeUsageBindingType)
+			if err != nil {
+				a.log.WithError(err).Errorf("could not read LB node usage")
+				return nil, err
+			}
+			res = append(res, *usage)
+		}
+	}
+	return res, nil
+}
+
 func (a *NSXApi) GetLBServiceInfo(lbID string) (*LBInfo, error) {
 	var err error
 
@@ -158,12 +234,19 @@ func (a *NSXApi) GetLBServiceInfo(lbID string) (*LBInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	// usage is optional, it is left nil when it can't be fetched
+	res.Usage, _ = a.getLBServiceUsage(lbID)
 
+	certs := map[string]model.TlsCertificate{}
 	for _, cStatus := range res.Status.VirtualServers {
 		config, err := a.getVirtualServer(PathToID(*cStatus.VirtualServerPath))
 		if err != nil {
 			return nil, err
 		}
+		vsCerts, err := a.getVirtualServerCertificates(config, certs)
+		if err != nil {
+			res.Incomplete = true
+		}
 		stats, err := search(func(l model.LBVirtualServerStatistics) string {
 			return *l.VirtualServerPath
 		}, *cStatus.VirtualServerPath, res.Stats.VirtualServers)
@@ -172,17 +255,23 @@ func (a *NSXApi) GetLBServiceInfo(lbID string) (*LBInfo, error) {
 			return nil, err
 		}
 		res.VirtualServers = append(res.VirtualServers, VSInfo{
-			Config: config,
-			Status: cStatus,
-			Stats:  *stats,
+			Config:       config,
+			Status:       cStatus,
+			Stats:        *stats,
+			Certificates: vsCerts,
 		})
 	}
 
+	monitors := map[string]MonitorInfo{}
 	for _, cStatus := range res.Status.Pools {
 		config, err := a.getPool(PathToID(*cStatus.PoolPath))
 		if err != nil {
 			return nil, err
 		}
+		poolMonitors, err := a.getPoolMonitors(config, monitors)
+		if err != nil {
+			res.Incomplete = true
+		}
 		stats, err := search(func(l model.LBPoolStatistics) string {
 			return *l.PoolPath
 		}, *cStatus.PoolPath, res.Stats.Pools)
@@ -202,16 +291,18 @@ func (a *NSXApi) GetLBServiceInfo(lbID string) (*LBInfo, error) {
 				return nil, err
 			}
 			members = append(members, MemberInfo{
+				Config: findPoolMember(config.Members, *cMember.IpAddress, *cMember.Port),
 				Stats:  *mStat,
 				Status: cMember,
 			})
 		}
 
 		res.Pools = append(res.Pools, PoolInfo{
-			Config:  config,
-			Status:  cStatus,
-			Stats:   *stats,
-			Members: members,
+			Config:   config,
+			Status:   cStatus,
+			Stats:    *stats,
+			Members:  members,
+			Monitors: poolMonitors,
 		})
 	}
 
@@ -231,6 +322,77 @@ func (a *NSXApi) getVirtualServer(vsID string) (*model.LBVirtualServer, error) {
 	return &config, nil
 }
 
+// getVirtualServerCertificates - resolves default and sni certificates of virtual server client
+// ssl profile binding, certs caches certificates by path as they are often shared between servers,
+// certificates that can't be fetched are skipped and the last error is returned with the others
+func (a *NSXApi) getVirtualServerCertificates(
+	vs *model.LBVirtualServer,
+	certs map[string]model.TlsCertificate,
+) ([]VSCertificateInfo, error) {
+	var lastErr error
+
+	res := []VSCertificateInfo{}
+	binding := vs.ClientSslProfileBinding
+	if binding == nil {
+		return res, nil
+	}
+
+	paths := map[string][]string{
+		"sni": binding.SniCertificatePaths,
+	}
+	if binding.DefaultCertificatePath != nil {
+		paths["default"] = []string{*binding.DefaultCertificatePath}
+	}
+
+	for _, cType := range []string{"default", "sni"} {
+		for _, cPath := range paths[cType] {
+			cert, ok := certs[cPath]
+			if !ok {
+				val, err := a.getCertificate(PathToID(cPath))
+				if err != nil {
+					lastErr = err
+					continue
+				}
+				cert = *val
+				certs[cPath] = cert
+			}
+			res = append(res, VSCertificateInfo{
+				Type:        cType,
+				Names:       certificateNames(cert),
+				Certificate: cert,
+			})
+		}
+	}
+
+	return res, lastErr
+}
+
+// certificateNames - subject common name and dns subject alternative names of leaf certificate,
+// alternative names are only available from pem content
+func certificateNames(cert model.TlsCertificate) []string {
+	res := []string{}
+	if len(cert.Details) != 0 && cert.Details[0].SubjectCn != nil {
+		res = append(res, *cert.Details[0].SubjectCn)
+	}
+	if cert.PemEncoded == nil {
+		return res
+	}
+	block, _ := pem.Decode([]byte(*cert.PemEncoded))
+	if block == nil {
+		return res
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return res
+	}
+	for _, cName := range leaf.DNSNames {
+		if !slices.Contains(res, cName) {
+			res = append(res, cName)
+		}
+	}
+	return res
+}
+
 func (a *NSXApi) getPool(poolID string) (*model.LBPool, error) {
 	a.log.Debugf("fetching pool load balancer '%s'", poolID)
 
@@ -243,3 +405,21 @@ func (a *NSXApi) getPool(poolID string) (*model.LBPool, error) {
 
 	return &config, nil
 }
+
+// findPoolMember - finds static pool member matching given ip and port, members without port
+// use virtual server port and are matched on ip only when no member matches both ip and port
+func findPoolMember(members []model.LBPoolMember, ip string, port string) *model.LBPoolMember {
+	var res *model.LBPoolMember
+	for idx, cMember := range members {
+		if cMember.IpAddress == nil || *cMember.IpAddress != ip {
+			continue
+		}
+		if cMember.Port != nil && *cMember.Port == port {
+			return &members[idx]
+		}
+		if cMember.Port == nil && res == nil {
+			res = &members[idx]
+		}
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// MonitorInfo - health monitor profile bound to a pool, Kind is either "active" or "passive",
+// MaxFails is only set for passive monitors, RiseCount and MonitorPort for active ones
+type MonitorInfo struct {
+	ID          string
+	Name        string
+	Path        string
+	Type        string
+	Kind        string
+	MonitorPort *int64
+	Interval    *int64
+	Timeout     *int64
+	FallCount   *int64
+	RiseCount   *int64
+	MaxFails    *int64
+}
+
+// getPoolMonitors - resolves active and passive monitors of given pool, monitors caches monitor
+// profiles by path as they are often shared between pools, monitors that can't be fetched are
+// skipped and the last error is returned with the others
+func (a *NSXApi) getPoolMonitors(pool *model.LBPool, monitors map[string]MonitorInfo) ([]MonitorInfo, error) {
+	var lastErr error
+
+	paths := map[string][]string{
+		"active": pool.ActiveMonitorPaths,
+	}
+	if pool.PassiveMonitorPath != nil {
+		paths["passive"] = []string{*pool.PassiveMonitorPath}
+	}
+
+	res := []MonitorInfo{}
+	for _, cKind := range []string{"active", "passive"} {
+		for _, cPath := range paths[cKind] {
+			monitor, ok := monitors[cPath]
+			if !ok {
+				val, err := a.getMonitorProfile(PathToID(cPath))
+				if err != nil {
+					lastErr = err
+					continue
+				}
+				monitor = *val
+				monitor.Kind = cKind
+				monitors[cPath] = monitor
+			}
+			res = append(res, monitor)
+		}
+	}
+	return res, lastErr
+}
+
+func (a *NSXApi) getMonitorProfile(monitorID string) (*MonitorInfo, error) {
+	a.log.Debugf("fetching monitor profile '%s'", monitorID)
+
+	cli := infra.NewLbMonitorProfilesClient(a.connector)
+	value, err := cli.Get(monitorID)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch monitor profile '%s'", monitorID)
+		return nil, err
+	}
+
+	base, err := convert[model.LBMonitorProfile](a, value, model.LBMonitorProfileBindingType)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not read monitor profile '%s'", monitorID)
+		return nil, err
+	}
+
+	res := MonitorInfo{
+		ID:   monitorID,
+		Type: base.ResourceType,
+	}
+	if base.Id != nil {
+		res.ID = *base.Id
+	}
+	if base.DisplayName != nil {
+		res.Name = *base.DisplayName
+	}
+	if base.Path != nil {
+		res.Path = *base.Path
+	}
+
+	switch base.ResourceType {
+	case model.LBMonitorProfile_RESOURCE_TYPE_LBHTTPMONITORPROFILE:
+		var p *model.LBHttpMonitorProfile
+		p, err = convert[model.LBHttpMonitorProfile](a, value, model.LBHttpMonitorProfileBindingType)
+		if err == nil {
+			res.MonitorPort, res.Interval, res.Timeout, res.FallCount, res.RiseCount = p.MonitorPort, p.Interval, p.Timeout, p.FallCount, p.RiseCount
+		}
+	case model.LBMonitorProfile_RESOURCE_TYPE_LBHTTPSMONITORPROFILE:
+		var p *model.LBHttpsMonitorProfile
+		p, err = convert[model.LBHttpsMonitorProfile](a, value, model.LBHttpsMonitorProfileBindingType)
+		if err == nil {
+			res.MonitorPort, res.Interval, res.Timeout, res.FallCount, res.RiseCount = p.MonitorPort, p.Interval, p.Timeout, p.FallCount, p.RiseCount
+		}
+	case model.LBMonitorProfile_RESOURCE_TYPE_LBICMPMONITORPROFILE:
+		var p *model.LBIcmpMonitorProfile
+		p, err = convert[model.LBIcmpMonitorProfile](a, value, model.LBIcmpMonitorProfileBindingType)
+		if err == nil {
+			res.MonitorPort, res.Interval, res.Timeout, res.FallCount, res.RiseCount = p.MonitorPort, p.Interval, p.Timeout, p.FallCount, p.RiseCount
+		}
+	case model.LBMonitorProfile_RESOURCE_TYPE_LBTCPMONITORPROFILE:
+		var p *model.LBTcpMonitorProfile
+		p, err = convert[model.LBTcpMonitorProfile](a, value, model.LBTcpMonitorProfileBindingType)
+		if err == nil {
+			res.MonitorPort, res.Interval, res.Timeout, res.FallCount, res.RiseCount = p.MonitorPort, p.Interval, p.Timeout, p.FallCount, p.RiseCount
+		}
+	case model.LBMonitorProfile_RESOURCE_TYPE_LBUDPMONITORPROFILE:
+		var p *model.LBUdpMonitorProfile
+		p, err = convert[model.LBUdpMonitorProfile](a, value, model.LBUdpMonitorProfileBindingType)
+		if err == nil {
+			res.MonitorPort, res.Interval, res.Timeout, res.FallCount, res.RiseCount = p.MonitorPort, p.Interval, p.Timeout, p.FallCount, p.RiseCount
+		}
+	case model.LBMonitorProfile_RESOURCE_TYPE_LBPASSIVEMONITORPROFILE:
+		var p *model.LBPassiveMonitorProfile
+		p, err = convert[model.LBPassiveMonitorProfile](a, value, model.LBPassiveMonitorProfileBindingType)
+		if err == nil {
+			res.Timeout, res.MaxFails = p.Timeout, p.MaxFails
+		}
+	}
+	if err != nil {
+		a.log.WithError(err).Errorf("could not read monitor profile '%s'", monitorID)
+		return nil, err
+	}
+
+	return &res, nil
+}
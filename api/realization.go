@@ -0,0 +1,70 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/realized_state"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	nsxSearch "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/search"
+)
+
+type RealizationInfo struct {
+	Intent   model.PolicyConfigResource
+	Entities []model.GenericPolicyRealizedResource
+}
+
+// ListRealizationIntents - lists policy objects of configured realization types
+func (a *NSXApi) ListRealizationIntents() ([]model.PolicyConfigResource, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching realization intents list")
+	res := []model.PolicyConfigResource{}
+	cli := nsxSearch.NewQueryClient(a.connector)
+
+	types := []string{}
+	for _, cType := range a.config.RealizationTypes {
+		types = append(types, fmt.Sprintf("resource_type:%s", cType))
+	}
+	query := strings.Join(types, " OR ")
+
+	for {
+		intents, err := cli.List(query, cursor, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list realization intents")
+			return nil, err
+		}
+
+		for _, cRes := range intents.Results {
+			intent, err := convert[model.PolicyConfigResource](a, cRes, model.PolicyConfigResourceBindingType)
+			if err != nil {
+				a.log.WithError(err).Errorf("could not read realization intent")
+				return nil, err
+			}
+			res = append(res, *intent)
+		}
+
+		cursor = intents.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) GetRealizationInfo(intent model.PolicyConfigResource) (*RealizationInfo, error) {
+	a.log.Debugf("fetching realized entities of '%s'", *intent.Path)
+
+	cli := realized_state.NewRealizedEntitiesClient(a.connector)
+	entities, err := cli.List(*intent.Path, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not list realized entities of '%s'", *intent.Path)
+		return nil, err
+	}
+
+	return &RealizationInfo{
+		Intent:   intent,
+		Entities: entities.Results,
+	}, nil
+}
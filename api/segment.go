@@ -0,0 +1,221 @@
+package api
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/go-vmware-nsxt/manager"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/segments"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s"
+	t1Segments "github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra/tier_1s/segments"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+	"golang.org/x/exp/slices"
+)
+
+// SegmentInfo - state, statistics and ports of a segment, TierID is set only for
+// segments defined under a tier1 gateway, TransportType is the type of the segment
+// transport zone (OVERLAY or VLAN)
+type SegmentInfo struct {
+	TierID        string
+	TransportType string
+	Config        model.Segment
+	State         model.SegmentConfigurationState
+	Stats         model.SegmentStatistics
+	Ports         []model.SegmentPort
+	PortStatus    *manager.LogicalPortStatusSummary
+}
+
+func (a *NSXApi) ListSegments() ([]model.Segment, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching segments list")
+	res := []model.Segment{}
+	cli := infra.NewSegmentsClient(a.connector)
+
+	for {
+		segments, err := cli.List(cursor, &False, nil, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list segments")
+			return nil, err
+		}
+		res = append(res, a.filterSegments(segments.Results)...)
+
+		cursor = segments.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) ListT1Segments(tierID string) ([]model.Segment, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching segments list of gateway '%s'", tierID)
+	res := []model.Segment{}
+	cli := tier_1s.NewSegmentsClient(a.connector)
+
+	for {
+		segments, err := cli.List(tierID, cursor, &False, nil, nil, nil, nil, nil)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list segments of gateway '%s'", tierID)
+			return nil, err
+		}
+		res = append(res, a.filterSegments(segments.Results)...)
+
+		cursor = segments.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// filterSegments - keeps segments matching segment_filters
+func (a *NSXApi) filterSegments(segments []model.Segment) []model.Segment {
+	res := []model.Segment{}
+	for _, cRes := range segments {
+		empty := len(a.config.SegmentFilters) == 0
+		hasName := slices.Contains(a.config.SegmentFilters, *cRes.DisplayName)
+		hasID := slices.Contains(a.config.SegmentFilters, *cRes.Id)
+		if empty || hasName || hasID {
+			log.Debugf("found segment '%s' (%s)", *cRes.DisplayName, *cRes.Id)
+			res = append(res, cRes)
+		}
+	}
+	return res
+}
+
+func (a *NSXApi) GetSegmentInfo(segment model.Segment, zones []manager.TransportZone) (*SegmentInfo, error) {
+	var err error
+
+	info := SegmentInfo{
+		Config: segment,
+	}
+	if segment.ParentPath != nil && strings.HasPrefix(*segment.ParentPath, "/infra/tier-1s/") {
+		info.TierID = PathToID(*segment.ParentPath)
+	}
+
+	// segments without transport zone are attached to the default overlay zone
+	info.TransportType = "OVERLAY"
+	if segment.TransportZonePath != nil {
+		info.TransportType = ""
+		zoneID := PathToID(*segment.TransportZonePath)
+		for _, cZone := range zones {
+			if cZone.Id == zoneID {
+				info.TransportType = cZone.TransportType
+			}
+		}
+	}
+
+	info.State, err = a.getSegmentState(info.TierID, *segment.Id)
+	if err != nil {
+		return nil, err
+	}
+	info.Stats, err = a.getSegmentStats(info.TierID, *segment.Id)
+	if err != nil {
+		return nil, err
+	}
+	info.Ports, err = a.listSegmentPorts(info.TierID, *segment.Id)
+	if err != nil {
+		return nil, err
+	}
+	// port status is read from manager api which needs the segment unique id
+	if segment.UniqueId != nil {
+		status, err := a.getSegmentPortStatus(*segment.Id, *segment.UniqueId)
+		if err != nil {
+			return nil, err
+		}
+		info.PortStatus = &status
+	}
+	return &info, nil
+}
+
+func (a *NSXApi) getSegmentState(tierID string, segmentID string) (model.SegmentConfigurationState, error) {
+	var state model.SegmentConfigurationState
+	var err error
+
+	a.log.Debugf("fetching state of segment '%s'", segmentID)
+	if tierID == "" {
+		cli := segments.NewStateClient(a.connector)
+		state, err = cli.Get(segmentID, nil, nil, nil, &False, nil, nil, nil, nil, nil, nil, nil)
+	} else {
+		cli := t1Segments.NewStateClient(a.connector)
+		state, err = cli.Get(tierID, segmentID, nil, nil, nil, &False, nil, nil, nil, nil, nil, nil, nil)
+	}
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch segment '%s' state", segmentID)
+		return state, err
+	}
+	return state, nil
+}
+
+func (a *NSXApi) getSegmentStats(tierID string, segmentID string) (model.SegmentStatistics, error) {
+	var stats model.SegmentStatistics
+	var err error
+
+	a.log.Debugf("fetching statistics of segment '%s'", segmentID)
+	if tierID == "" {
+		cli := segments.NewStatisticsClient(a.connector)
+		stats, err = cli.Get(segmentID, nil, nil, nil, &False, nil, nil, nil, nil, nil, nil, nil)
+	} else {
+		cli := t1Segments.NewStatisticsClient(a.connector)
+		stats, err = cli.Get(tierID, segmentID, nil, nil, nil, &False, nil, nil, nil, nil, nil, nil, nil)
+	}
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch segment '%s' statistics", segmentID)
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (a *NSXApi) listSegmentPorts(tierID string, segmentID string) ([]model.SegmentPort, error) {
+	var cursor *string
+
+	a.log.Debugf("fetching ports of segment '%s'", segmentID)
+	res := []model.SegmentPort{}
+
+	for {
+		var ports model.SegmentPortListResult
+		var err error
+		if tierID == "" {
+			cli := segments.NewPortsClient(a.connector)
+			ports, err = cli.List(segmentID, cursor, &False, nil, nil, nil, nil)
+		} else {
+			cli := t1Segments.NewPortsClient(a.connector)
+			ports, err = cli.List(tierID, segmentID, cursor, &False, nil, nil, nil, nil)
+		}
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list ports of segment '%s'", segmentID)
+			return nil, err
+		}
+		res = append(res, ports.Results...)
+
+		cursor = ports.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// getSegmentPortStatus - operational status of ports is only summarized by the manager api,
+// segment unique id is the id of its realized logical switch
+func (a *NSXApi) getSegmentPortStatus(segmentID string, switchID string) (manager.LogicalPortStatusSummary, error) {
+	a.log.Debugf("fetching port status summary of segment '%s'", segmentID)
+
+	opts := map[string]interface{}{
+		"logicalSwitchId": switchID,
+	}
+	// nolint: bodyclose
+	summary, _, err := a.client.LogicalSwitchingApi.GetLogicalPortStatusSummary(a.client.Context, opts)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch port status summary of segment '%s'", segmentID)
+		return summary, err
+	}
+	return summary, nil
+}
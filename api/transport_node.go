@@ -0,0 +1,122 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+// HostNodeInfo - status and tunnels of a host transport node
+type HostNodeInfo struct {
+	Node    manager.TransportNode
+	Status  manager.TransportNodeStatus
+	Tunnels []manager.TunnelProperties
+}
+
+func (a *NSXApi) listTransportNodes(nodeType string) ([]manager.TransportNode, error) {
+	a.log.Debugf("fetching transport nodes list of type '%s'", nodeType)
+	res := []manager.TransportNode{}
+	opts := map[string]interface{}{
+		"nodeTypes": nodeType,
+	}
+
+	for {
+		// nolint: bodyclose
+		nodes, _, err := a.client.NetworkTransportApi.ListTransportNodes(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list transport nodes of type '%s'", nodeType)
+			return nil, err
+		}
+		res = append(res, nodes.Results...)
+
+		if nodes.Cursor == "" {
+			break
+		}
+		opts["cursor"] = nodes.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) ListHostNodes() ([]manager.TransportNode, error) {
+	return a.listTransportNodes("HostNode")
+}
+
+func (a *NSXApi) ListTransportNodeStatus() ([]manager.TransportNodeStatus, error) {
+	a.log.Debugf("fetching transport nodes status list")
+	res := []manager.TransportNodeStatus{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		statuses, _, err := a.client.TroubleshootingAndMonitoringApi.ListTransportNodeStatus(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list transport nodes status")
+			return nil, err
+		}
+		res = append(res, statuses.Results...)
+
+		if statuses.Cursor == "" {
+			break
+		}
+		opts["cursor"] = statuses.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) GetHostNodeInfo(node manager.TransportNode, statuses []manager.TransportNodeStatus) (*HostNodeInfo, error) {
+	var err error
+
+	info := HostNodeInfo{
+		Node: node,
+	}
+	info.Status, err = a.getTransportNodeStatus(node.Id, statuses)
+	if err != nil {
+		return nil, err
+	}
+	info.Tunnels, err = a.listTunnels(node.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// getTransportNodeStatus - reads node status from given status list, falls back to a dedicated
+// request when node is missing from the list
+func (a *NSXApi) getTransportNodeStatus(nodeID string, statuses []manager.TransportNodeStatus) (manager.TransportNodeStatus, error) {
+	reader := func(s manager.TransportNodeStatus) string { return s.NodeUuid }
+	if status, err := search(reader, nodeID, statuses); err == nil {
+		return *status, nil
+	}
+
+	a.log.Debugf("fetching transport node '%s' status", nodeID)
+	// nolint: bodyclose
+	status, _, err := a.client.TroubleshootingAndMonitoringApi.GetTransportNodeStatus(a.client.Context, nodeID, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch transport node '%s' status", nodeID)
+		return status, err
+	}
+	return status, nil
+}
+
+func (a *NSXApi) listTunnels(nodeID string) ([]manager.TunnelProperties, error) {
+	a.log.Debugf("fetching tunnels of transport node '%s'", nodeID)
+	res := []manager.TunnelProperties{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		tunnels, _, err := a.client.TransportEntitiesApi.QueryTunnels(a.client.Context, nodeID, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list tunnels of transport node '%s'", nodeID)
+			return nil, err
+		}
+		res = append(res, tunnels.Tunnels...)
+
+		if tunnels.Cursor == "" {
+			break
+		}
+		opts["cursor"] = tunnels.Cursor
+	}
+
+	return res, nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/manager"
+	"github.com/vmware/go-vmware-nsxt/monitoring"
+)
+
+type TransportZoneInfo struct {
+	Zone    manager.TransportZone
+	Status  manager.TransportZoneStatus
+	Heatmap monitoring.HeatMapTransportZoneStatus
+}
+
+func (a *NSXApi) ListTransportZones() ([]manager.TransportZone, error) {
+	a.log.Debugf("fetching transport zones list")
+	res := []manager.TransportZone{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		zones, _, err := a.client.NetworkTransportApi.ListTransportZones(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list transport zones")
+			return nil, err
+		}
+		res = append(res, zones.Results...)
+
+		if zones.Cursor == "" {
+			break
+		}
+		opts["cursor"] = zones.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) GetTransportZoneInfo(zone manager.TransportZone) (*TransportZoneInfo, error) {
+	var err error
+
+	a.log.Debugf("fetching transport zone '%s' status", zone.Id)
+	info := TransportZoneInfo{
+		Zone: zone,
+	}
+
+	// nolint: bodyclose
+	info.Status, _, err = a.client.NetworkTransportApi.GetTransportZoneStatus(a.client.Context, zone.Id)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch transport zone '%s' status", zone.Id)
+		return nil, err
+	}
+
+	// nolint: bodyclose
+	info.Heatmap, _, err = a.client.TroubleshootingAndMonitoringApi.GetHeatmapTransportZoneStatus(a.client.Context, zone.Id, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch transport zone '%s' heatmap status", zone.Id)
+		return nil, err
+	}
+
+	return &info, nil
+}
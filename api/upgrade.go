@@ -0,0 +1,90 @@
+package api
+
+import (
+	"github.com/vmware/go-vmware-nsxt/upgrade"
+)
+
+// UpgradeInfo - upgrade progress, Groups are indexed by component type (MP, EDGE, HOST)
+type UpgradeInfo struct {
+	Status upgrade.UpgradeStatus
+	Groups map[string][]upgrade.UpgradeUnitGroupStatus
+	Units  []upgrade.UpgradeUnit
+}
+
+func (a *NSXApi) GetUpgradeInfo() (*UpgradeInfo, error) {
+	var err error
+
+	info := UpgradeInfo{
+		Groups: map[string][]upgrade.UpgradeUnitGroupStatus{},
+	}
+
+	a.log.Debugf("fetching upgrade status summary")
+	// nolint: bodyclose
+	info.Status, _, err = a.client.UpgradeApi.GetUpgradeStatusSummary(a.client.Context, nil)
+	if err != nil {
+		a.log.WithError(err).Errorf("could not fetch upgrade status summary")
+		return nil, err
+	}
+
+	for _, cComponent := range info.Status.ComponentStatus {
+		info.Groups[cComponent.ComponentType], err = a.listUpgradeUnitGroupsStatus(cComponent.ComponentType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	info.Units, err = a.listUpgradeUnits()
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func (a *NSXApi) listUpgradeUnitGroupsStatus(component string) ([]upgrade.UpgradeUnitGroupStatus, error) {
+	a.log.Debugf("fetching upgrade unit groups status of component '%s'", component)
+	res := []upgrade.UpgradeUnitGroupStatus{}
+	opts := map[string]interface{}{
+		"componentType": component,
+	}
+
+	for {
+		// nolint: bodyclose
+		groups, _, err := a.client.UpgradeApi.GetUpgradeUnitGroupsStatus(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list upgrade unit groups status of component '%s'", component)
+			return nil, err
+		}
+		res = append(res, groups.Results...)
+
+		if groups.Cursor == "" {
+			break
+		}
+		opts["cursor"] = groups.Cursor
+	}
+
+	return res, nil
+}
+
+func (a *NSXApi) listUpgradeUnits() ([]upgrade.UpgradeUnit, error) {
+	a.log.Debugf("fetching upgrade units list")
+	res := []upgrade.UpgradeUnit{}
+	opts := map[string]interface{}{}
+
+	for {
+		// nolint: bodyclose
+		units, _, err := a.client.UpgradeApi.GetUpgradeUnits(a.client.Context, opts)
+		if err != nil {
+			a.log.WithError(err).Errorf("could not list upgrade units")
+			return nil, err
+		}
+		res = append(res, units.Results...)
+
+		if units.Cursor == "" {
+			break
+		}
+		opts["cursor"] = units.Cursor
+	}
+
+	return res, nil
+}
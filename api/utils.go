@@ -1,8 +1,19 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/config"
+	vapiErrors_ "github.com/vmware/vsphere-automation-sdk-go/lib/vapi/std/errors"
+	vapiBindings_ "github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
+	vapiData_ "github.com/vmware/vsphere-automation-sdk-go/runtime/data"
 )
 
 func PathToID(path string) string {
@@ -18,3 +29,115 @@ func search[T any](reader func(T) string, needle string, array []T) (*T, error)
 	}
 	return nil, fmt.Errorf("could not find object '%s' in collection", needle)
 }
+
+// match - tells if any of given values matches one of the filters, always true when filters are empty
+func match(filters []config.Regexp, values ...string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, cFilter := range filters {
+		for _, cValue := range values {
+			if cFilter.MatchString(cValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// convert - converts polymorphic policy api value to given golang model type
+func convert[T any](a *NSXApi, value *vapiData_.StructValue, binding func() vapiBindings_.BindingType) (*T, error) {
+	t := a.connector.TypeConverter()
+	s, errs := t.ConvertToGolang(value, vapiBindings_.NewReferenceType(binding))
+	if len(errs) != 0 {
+		return nil, errs[0]
+	}
+	val := s.(T)
+	return &val, nil
+}
+
+// first - converts the first element of a per-enforcement-point result list
+func first[T any](a *NSXApi, values []*vapiData_.StructValue, binding func() vapiBindings_.BindingType) (*T, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("not found")
+	}
+	return convert[T](a, values[0], binding)
+}
+
+// shouldRetry - tells if request must be retried given its response, nil on transport error
+func shouldRetry(resp *http.Response) bool {
+	if resp == nil {
+		return true
+	}
+	for _, code := range retryCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// statusError - unexpected http status code returned by getJSON
+type statusError struct {
+	code int
+	path string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d for '%s'", e.code, e.path)
+}
+
+// isNotFound - tells if given policy or manager api error means that requested object does not exist
+func isNotFound(err error) bool {
+	switch e := err.(type) {
+	case vapiErrors_.NotFound, *vapiErrors_.NotFound:
+		return true
+	case *statusError:
+		return e.code == http.StatusNotFound
+	}
+	return false
+}
+
+// getJSON - performs a raw GET request on manager api for endpoints that are not covered
+// by vendored sdks, and decodes json response into given value, request is retried like
+// vendored clients on transport errors and retryCodes
+func (a *NSXApi) getJSON(path string, query url.Values, value interface{}) error {
+	target := strings.TrimSuffix(a.config.URL, "/") + path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if a.config.NeedPasswordLogin() {
+		req.SetBasicAuth(a.config.Username, a.config.Password)
+	}
+
+	var resp *http.Response
+	for try := 1; ; try++ {
+		resp, err = a.httpClient.Do(req)
+		if try > a.config.MaxRetries || !shouldRetry(resp) {
+			break
+		}
+		if resp != nil {
+			a.log.Debugf("retrying request '%s' due to error code %d", path, resp.StatusCode)
+			resp.Body.Close()
+		} else {
+			a.log.Debugf("retrying request '%s' due to error", path)
+		}
+		// nolint:gosec
+		time.Sleep(time.Duration(rand.Intn(retryMaxDelay)) * time.Millisecond)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &statusError{code: resp.StatusCode, path: path}
+	}
+	return json.NewDecoder(resp.Body).Decode(value)
+}
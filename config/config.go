@@ -72,6 +72,7 @@ type exporterConfig struct {
 	Port                  int           `yaml:"port"`
 	Path                  string        `yaml:"path"`
 	Namespace             string        `yaml:"namespace"`
+	LegacyGauges          bool          `yaml:"legacy_gauges"`
 }
 
 func (c *exporterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -95,18 +96,25 @@ func (c *exporterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 }
 
 type NSXConfig struct {
-	URL            string   `yaml:"url"`
-	Username       string   `yaml:"username"`
-	Password       string   `yaml:"password"`
-	ClientCertPath string   `yaml:"client_cert_path"`
-	ClientKeyPath  string   `yaml:"client_key_path"`
-	SkipSslVerify  bool     `yaml:"skip_ssl_verify"`
-	CaCertPath     string   `yaml:"ca_cert_path"`
-	MaxRetries     int      `yaml:"max_retries"`
-	T0Filters      []string `yaml:"t0_filters"`
-	T1Filters      []string `yaml:"t1_filters"`
-	LBFilters      []string `yaml:"lb_filters"`
-	VSFilters      []string `yaml:"vs_filters"`
+	URL                  string   `yaml:"url"`
+	Username             string   `yaml:"username"`
+	Password             string   `yaml:"password"`
+	ClientCertPath       string   `yaml:"client_cert_path"`
+	ClientKeyPath        string   `yaml:"client_key_path"`
+	SkipSslVerify        bool     `yaml:"skip_ssl_verify"`
+	CaCertPath           string   `yaml:"ca_cert_path"`
+	MaxRetries           int      `yaml:"max_retries"`
+	T0Filters            []string `yaml:"t0_filters"`
+	T1Filters            []string `yaml:"t1_filters"`
+	SegmentFilters       []string `yaml:"segment_filters"`
+	LBFilters            []string `yaml:"lb_filters"`
+	VSFilters            []string `yaml:"vs_filters"`
+	DFWDomainFilters     []Regexp `yaml:"dfw_domain_filters"`
+	DFWPolicyFilters     []Regexp `yaml:"dfw_policy_filters"`
+	DFWMaxRulesPerPolicy int      `yaml:"dfw_max_rules_per_policy"`
+	AlarmFeatureFilters  []Regexp `yaml:"alarm_feature_filters"`
+	AlarmSeverityFilters []Regexp `yaml:"alarm_severity_filters"`
+	RealizationTypes     []string `yaml:"realization_types"`
 }
 
 func (n *NSXConfig) NeedPasswordLogin() bool {
@@ -144,6 +152,12 @@ func (n *NSXConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if n.MaxRetries == 0 {
 		n.MaxRetries = 3
 	}
+	if n.DFWMaxRulesPerPolicy == 0 {
+		n.DFWMaxRulesPerPolicy = 1000
+	}
+	if len(n.RealizationTypes) == 0 {
+		n.RealizationTypes = []string{"Tier0", "Tier1", "Segment"}
+	}
 	return nil
 }
 
@@ -42,7 +42,7 @@ func main() {
 	logrus.SetLevel(lvl)
 	log.SetLogger(logrus.StandardLogger())
 
-	recorder := metrics.NewRecorder(manager, namespace)
+	recorder := metrics.NewRecorder(manager, namespace, object.Exporter.LegacyGauges)
 
 	go func() {
 		for {
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// alarm{feature, event_type, severity, entity_id, node} 1
+// alarm_count{severity}
+
+type AlarmMetrics struct {
+	alarm prometheus.GaugeVec
+	count prometheus.GaugeVec
+}
+
+func NewAlarmMetrics(namespace string) *AlarmMetrics {
+	return &AlarmMetrics{
+		alarm: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "alarm",
+				Help:      "Open alarm raised by NSX, value is always 1",
+			}, []string{"feature", "event_type", "severity", "entity_id", "node"}),
+		count: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "alarm_count",
+				Help:      "Number of open alarms raised by NSX by severity",
+			}, []string{"severity"}),
+	}
+}
+
+func (m *AlarmMetrics) Reset() {
+	m.alarm.Reset()
+	m.count.Reset()
+}
+
+// Populate - count is exported for given severities, even when they have no open alarm
+func (m *AlarmMetrics) Populate(alarms []api.Alarm, severities []string) {
+	counts := map[string]int{}
+	for _, cSeverity := range severities {
+		counts[cSeverity] = 0
+	}
+
+	for _, cAlarm := range alarms {
+		labels := []string{
+			cAlarm.FeatureName,
+			cAlarm.EventType,
+			cAlarm.Severity,
+			cAlarm.EntityID,
+			cAlarm.NodeID,
+		}
+		set(m.alarm, labels, 1)
+		counts[cAlarm.Severity]++
+	}
+
+	for cSeverity, cCount := range counts {
+		set(m.count, []string{cSeverity}, cCount)
+	}
+}
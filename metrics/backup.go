@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/go-vmware-nsxt/administration"
+)
+
+// backup_enabled{schedule}
+// backup_frequency_seconds
+// backup_running
+// backup_last_status{type, error_code}
+// backup_last_success_timestamp{type}
+// backup_last_failure_timestamp{type, error_code}
+
+type BackupMetrics struct {
+	enabled     prometheus.GaugeVec
+	frequency   prometheus.Gauge
+	running     prometheus.Gauge
+	lastStatus  prometheus.GaugeVec
+	lastSuccess prometheus.GaugeVec
+	lastFailure prometheus.GaugeVec
+}
+
+func NewBackupMetrics(namespace string) *BackupMetrics {
+	return &BackupMetrics{
+		enabled: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "backup_enabled",
+				Help:      "Is scheduled backup enabled, 1 when enabled",
+			}, []string{"schedule"}),
+		frequency: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "backup_frequency_seconds",
+				Help:      "Average number of seconds between two scheduled backups",
+			}),
+		running: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "backup_running",
+				Help:      "Is a backup currently running, 1 when running",
+			}),
+		lastStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "backup_last_status",
+				Help:      "Status of last backup by type, 1 when successful",
+			}, []string{"type", "error_code"}),
+		lastSuccess: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "backup_last_success_timestamp",
+				Help:      "End of last successful backup by type expressed in number of second since EPOCH",
+			}, []string{"type"}),
+		lastFailure: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "backup_last_failure_timestamp",
+				Help:      "End of last failed backup by type expressed in number of second since EPOCH",
+			}, []string{"type", "error_code"}),
+	}
+}
+
+func (m *BackupMetrics) Reset() {
+	m.enabled.Reset()
+	m.frequency.Set(0)
+	m.running.Set(0)
+	m.lastStatus.Reset()
+	m.lastSuccess.Reset()
+	m.lastFailure.Reset()
+}
+
+func (m *BackupMetrics) Populate(info api.BackupInfo) {
+	schedule := "none"
+	if info.Config.BackupSchedule != nil {
+		schedule = info.Config.BackupSchedule.ResourceType
+	}
+	setb(m.enabled, []string{schedule}, &info.Config.BackupEnabled)
+	if info.Config.BackupEnabled && info.Config.BackupSchedule != nil {
+		m.frequency.Set(float64(backupFrequency(*info.Config.BackupSchedule)))
+	}
+	m.running.Set(statusToValue(info.Status.OperationType, "BACKUP"))
+
+	m.populateHistory("cluster", info.History.ClusterBackupStatuses)
+	m.populateHistory("node", info.History.NodeBackupStatuses)
+	m.populateHistory("inventory", info.History.InventoryBackupStatuses)
+}
+
+func (m *BackupMetrics) populateHistory(kind string, statuses []administration.BackupOperationStatus) {
+	var last, lastSuccess, lastFailure *administration.BackupOperationStatus
+	for idx := range statuses {
+		cStatus := &statuses[idx]
+		if last == nil || cStatus.EndTime > last.EndTime {
+			last = cStatus
+		}
+		if cStatus.Success && (lastSuccess == nil || cStatus.EndTime > lastSuccess.EndTime) {
+			lastSuccess = cStatus
+		}
+		if !cStatus.Success && (lastFailure == nil || cStatus.EndTime > lastFailure.EndTime) {
+			lastFailure = cStatus
+		}
+	}
+
+	if last != nil {
+		setb(m.lastStatus, []string{kind, last.ErrorCode}, &last.Success)
+	}
+	if lastSuccess != nil {
+		set(m.lastSuccess, []string{kind}, lastSuccess.EndTime/1000)
+	}
+	if lastFailure != nil {
+		set(m.lastFailure, []string{kind, lastFailure.ErrorCode}, lastFailure.EndTime/1000)
+	}
+}
+
+// backupFrequency - seconds between backups, weekly schedules are averaged over the week
+func backupFrequency(schedule api.BackupSchedule) int64 {
+	switch schedule.ResourceType {
+	case "IntervalBackupSchedule":
+		return schedule.SecondsBetweenBackups
+	case "WeeklyBackupSchedule":
+		if len(schedule.DaysOfWeek) != 0 {
+			return 7 * 24 * 3600 / int64(len(schedule.DaysOfWeek))
+		}
+	}
+	return 0
+}
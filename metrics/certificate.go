@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/go-vmware-nsxt/trust"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// certificate_info{id, name, kind, index, subject, issuer, algorithm, ca} 1
+// certificate_not_after{id, name, kind, index}
+// certificate_not_before{id, name, kind, index}
+// certificate_key_size{id, name, kind, index}
+// certificate_used_by{id, name, node_id, service} 1
+// crl_next_update{id, name, issuer}
+// crl_revoked{id, name}
+
+type CertificateMetrics struct {
+	info       prometheus.GaugeVec
+	notAfter   prometheus.GaugeVec
+	notBefore  prometheus.GaugeVec
+	keySize    prometheus.GaugeVec
+	usedBy     prometheus.GaugeVec
+	crlUpdate  prometheus.GaugeVec
+	crlRevoked prometheus.GaugeVec
+}
+
+func NewCertificateMetrics(namespace string) *CertificateMetrics {
+	labels := []string{"id", "name", "kind", "index"}
+	return &CertificateMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_info",
+				Help:      "Give informations as label about trust store certificate, value is always 1",
+			}, slice(labels, "subject", "issuer", "algorithm", "ca")),
+		notAfter: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_not_after",
+				Help:      "Certificate validity end date expressed in number of second since EPOCH",
+			}, labels),
+		notBefore: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_not_before",
+				Help:      "Certificate validity start date expressed in number of second since EPOCH",
+			}, labels),
+		keySize: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_key_size",
+				Help:      "Certificate public key length in bits",
+			}, labels),
+		usedBy: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_used_by",
+				Help:      "Service using the certificate on given node, value is always 1",
+			}, []string{"id", "name", "node_id", "service"}),
+		crlUpdate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "crl_next_update",
+				Help:      "CRL next update date expressed in number of second since EPOCH",
+			}, []string{"id", "name", "issuer"}),
+		crlRevoked: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "crl_revoked",
+				Help:      "Number of certificates revoked by CRL",
+			}, []string{"id", "name"}),
+	}
+}
+
+func (m *CertificateMetrics) Reset() {
+	m.info.Reset()
+	m.notAfter.Reset()
+	m.notBefore.Reset()
+	m.keySize.Reset()
+	m.usedBy.Reset()
+	m.crlUpdate.Reset()
+	m.crlRevoked.Reset()
+}
+
+func (m *CertificateMetrics) PopulateCertificates(certs []trust.Certificate) {
+	for _, cCert := range certs {
+		for idx, cDetail := range cCert.Details {
+			labels := []string{cCert.Id, cCert.DisplayName, "certificate", fmt.Sprintf("%d", idx)}
+			set(m.info, slice(labels, cDetail.Subject, cDetail.Issuer, cDetail.PublicKeyAlgo, strconv.FormatBool(cDetail.IsCa)), 1)
+			set(m.notAfter, labels, cDetail.NotAfter/1000)
+			set(m.notBefore, labels, cDetail.NotBefore/1000)
+			set(m.keySize, labels, cDetail.PublicKeyLength)
+		}
+		for _, cUsage := range cCert.UsedBy {
+			for _, cService := range cUsage.ServiceTypes {
+				set(m.usedBy, []string{cCert.Id, cCert.DisplayName, cUsage.NodeId, cService}, 1)
+			}
+		}
+	}
+}
+
+func (m *CertificateMetrics) PopulateCaBundles(bundles []model.CaBundle) {
+	for _, cBundle := range bundles {
+		for idx, cDetail := range cBundle.Certificates {
+			labels := []string{zero(cBundle.Id), zero(cBundle.DisplayName), "ca_bundle", fmt.Sprintf("%d", idx)}
+			set(m.info, slice(labels, zero(cDetail.Subject), zero(cDetail.Issuer), zero(cDetail.PublicKeyAlgo), strconv.FormatBool(zero(cDetail.IsCa))), 1)
+			set(m.notAfter, labels, zero(cDetail.NotAfter)/1000)
+			set(m.notBefore, labels, zero(cDetail.NotBefore)/1000)
+			setp(m.keySize, labels, cDetail.PublicKeyLength)
+		}
+	}
+}
+
+// PopulateCrls - next update date is read from pem encoded crl because api gives it as
+// an unspecified string format, OneCRL typed crls have no pem content and are skipped
+func (m *CertificateMetrics) PopulateCrls(crls []model.TlsCrl) {
+	for _, cCrl := range crls {
+		block, _ := pem.Decode([]byte(zero(cCrl.PemEncoded)))
+		if block == nil {
+			continue
+		}
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			log.WithError(err).Errorf("error while reading crl '%s'", zero(cCrl.Id))
+			continue
+		}
+		labels := []string{zero(cCrl.Id), zero(cCrl.DisplayName)}
+		set(m.crlUpdate, slice(labels, crl.Issuer.String()), crl.NextUpdate.Unix())
+		set(m.crlRevoked, labels, len(crl.RevokedCertificateEntries))
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// cluster_status{status} 1 == STABLE
+// cluster_group_status{group_type, group_id, status} 1 == STABLE
+// cluster_group_member_status{group_type, group_id, uuid, fqdn, ip, status} 1 == UP
+// cluster_node_service_status{uuid, service, status} 1 == running
+
+type ClusterMetrics struct {
+	status        prometheus.GaugeVec
+	groupStatus   prometheus.GaugeVec
+	memberStatus  prometheus.GaugeVec
+	serviceStatus prometheus.GaugeVec
+}
+
+func NewClusterMetrics(namespace string) *ClusterMetrics {
+	groupLabels := []string{"group_type", "group_id"}
+	return &ClusterMetrics{
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "cluster_status",
+				Help:      "Overall cluster status, 1 means STABLE",
+			}, []string{"status"}),
+		groupStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "cluster_group_status",
+				Help:      "Cluster group status, 1 means STABLE",
+			}, slice(groupLabels, "status")),
+		memberStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "cluster_group_member_status",
+				Help:      "Cluster group member status, 1 means UP",
+			}, slice(groupLabels, "uuid", "fqdn", "ip", "status")),
+		serviceStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "cluster_node_service_status",
+				Help:      "Runtime state of service on cluster node, 1 means running",
+			}, []string{"uuid", "service", "status"}),
+	}
+}
+
+func (m *ClusterMetrics) Reset() {
+	m.status.Reset()
+	m.groupStatus.Reset()
+	m.memberStatus.Reset()
+	m.serviceStatus.Reset()
+}
+
+func (m *ClusterMetrics) Populate(status api.DetailedClusterStatus) {
+	set(m.status, []string{status.OverallStatus}, statusToValue(status.OverallStatus, StatusStable))
+	for _, cGroup := range status.Groups {
+		labels := []string{cGroup.Type, cGroup.ID}
+		set(m.groupStatus, slice(labels, cGroup.Status), statusToValue(cGroup.Status, StatusStable))
+		for _, cMember := range cGroup.Members {
+			memberLabels := slice(labels, cMember.UUID, cMember.FQDN, cMember.IP, cMember.Status)
+			set(m.memberStatus, memberLabels, statusToValue(cMember.Status, StatusUp))
+		}
+	}
+}
+
+func (m *ClusterMetrics) PopulateServices(nodeID string, services []api.NodeServiceInfo) {
+	for _, cService := range services {
+		state := cService.Status.RuntimeState
+		set(m.serviceStatus, []string{nodeID, cService.Name, state}, statusToValue(state, StatusRunning))
+	}
+}
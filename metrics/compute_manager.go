@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// compute_manager_info{id, name, server, type, version} 1
+// compute_manager_connection_status{id, name, status} 1 == UP
+// compute_manager_registration_status{id, name, status} 1 == REGISTERED
+// compute_manager_state{id, name, state} 1 == success
+// compute_manager_last_sync{id, name}
+// compute_manager_collection{id, name}
+// compute_manager_host{id, name}
+
+type ComputeManagerMetrics struct {
+	info               prometheus.GaugeVec
+	connectionStatus   prometheus.GaugeVec
+	registrationStatus prometheus.GaugeVec
+	state              prometheus.GaugeVec
+	lastSync           prometheus.GaugeVec
+	collection         prometheus.GaugeVec
+	host               prometheus.GaugeVec
+}
+
+func NewComputeManagerMetrics(namespace string) *ComputeManagerMetrics {
+	labels := []string{"id", "name"}
+	return &ComputeManagerMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_info",
+				Help:      "Give informations as label about compute manager, value is always 1",
+			}, slice(labels, "server", "type", "version")),
+		connectionStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_connection_status",
+				Help:      "Gives connection status of compute manager, 1 is UP",
+			}, slice(labels, "status")),
+		registrationStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_registration_status",
+				Help:      "Gives registration status of compute manager, 1 is REGISTERED",
+			}, slice(labels, "status")),
+		state: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_state",
+				Help:      "Gives configuration state of compute manager, 1 is success",
+			}, slice(labels, "state")),
+		lastSync: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_last_sync",
+				Help:      "Last inventory synchronization of compute manager expressed in number of second since EPOCH",
+			}, labels),
+		collection: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_collection",
+				Help:      "Number of compute collections discovered by compute manager",
+			}, labels),
+		host: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "compute_manager_host",
+				Help:      "Number of hosts discovered by compute manager",
+			}, labels),
+	}
+}
+
+func (m *ComputeManagerMetrics) Reset() {
+	m.info.Reset()
+	m.connectionStatus.Reset()
+	m.registrationStatus.Reset()
+	m.state.Reset()
+	m.lastSync.Reset()
+	m.collection.Reset()
+	m.host.Reset()
+}
+
+func (m *ComputeManagerMetrics) Populate(info api.ComputeManagerInfo) {
+	labels := []string{
+		info.Manager.Id,
+		info.Manager.DisplayName,
+	}
+
+	set(m.info, slice(labels, info.Manager.Server, info.Manager.OriginType, info.Status.Version), 1)
+	set(m.connectionStatus, slice(labels, info.Status.ConnectionStatus), statusToValue(info.Status.ConnectionStatus, StatusUp))
+	set(m.registrationStatus, slice(labels, info.Status.RegistrationStatus), statusToValue(info.Status.RegistrationStatus, StatusRegistered))
+	set(m.state, slice(labels, info.State.State), statusToValue(info.State.State, StatusSuccess))
+	set(m.lastSync, labels, info.Status.LastSyncTime/1000)
+	set(m.collection, labels, info.Collections)
+	set(m.host, labels, info.Hosts)
+}
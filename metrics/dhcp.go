@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// dhcp_server_status{id, name, connectivity, status} 1 == UP
+// dhcp_server_error{id, name, connectivity, message} 1
+// dhcp_server_lease{id, name, connectivity}
+// dhcp_server_message_total{id, name, connectivity, type}
+// dhcp_server_message_error_total{id, name, connectivity}
+// dhcp_pool_lease{id, name, connectivity, pool_id}
+// dhcp_pool_size{id, name, connectivity, pool_id}
+// dhcp_pool_usage{id, name, connectivity, pool_id} ratio
+// dhcp_relay_info{id, name, connectivity, server} 1
+
+type DHCPMetrics struct {
+	status       prometheus.GaugeVec
+	error        prometheus.GaugeVec
+	lease        prometheus.GaugeVec
+	message      *CounterVec
+	messageError *CounterVec
+	poolLease    prometheus.GaugeVec
+	poolSize     prometheus.GaugeVec
+	poolUsage    prometheus.GaugeVec
+	relay        prometheus.GaugeVec
+}
+
+func NewDHCPMetrics(namespace string) *DHCPMetrics {
+	labels := []string{"id", "name", "connectivity"}
+	return &DHCPMetrics{
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_server_status",
+				Help:      "Gives status of dhcp server, 1 is UP",
+			}, slice(labels, "status")),
+		error: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_server_error",
+				Help:      "Current error message for dhcp server if any, value is always 1",
+			}, slice(labels, "message")),
+		lease: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_server_lease",
+				Help:      "Number of allocated leases in all pools of dhcp server",
+			}, labels),
+		message: NewCounterVec(
+			namespace,
+			"dhcp_server_message",
+			"Total number of dhcp messages by type handled by dhcp server",
+			slice(labels, "type"), false),
+		messageError: NewCounterVec(
+			namespace,
+			"dhcp_server_message_error",
+			"Total number of dhcp errors of dhcp server",
+			labels, false),
+		poolLease: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_pool_lease",
+				Help:      "Number of allocated leases in dhcp pool",
+			}, slice(labels, "pool_id")),
+		poolSize: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_pool_size",
+				Help:      "Number of addresses in dhcp pool",
+			}, slice(labels, "pool_id")),
+		poolUsage: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_pool_usage",
+				Help:      "Ratio of allocated addresses in dhcp pool, between 0 and 1",
+			}, slice(labels, "pool_id")),
+		relay: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dhcp_relay_info",
+				Help:      "Give dhcp server address as label about dhcp relay, value is always 1",
+			}, slice(labels, "server")),
+	}
+}
+
+func (m *DHCPMetrics) Reset() {
+	m.status.Reset()
+	m.error.Reset()
+	m.lease.Reset()
+	m.message.Reset()
+	m.messageError.Reset()
+	m.poolLease.Reset()
+	m.poolSize.Reset()
+	m.poolUsage.Reset()
+	m.relay.Reset()
+}
+
+func (m *DHCPMetrics) Populate(info api.DHCPServerInfo) {
+	labels := []string{
+		info.ID,
+		info.Name,
+		info.ConnectivityPath,
+	}
+
+	setv(m.status, labels, info.Status.ServiceStatus, StatusUp)
+	if zero(info.Status.ErrorMessage) != "" {
+		set(m.error, slice(labels, *info.Status.ErrorMessage), 1)
+	}
+
+	setcp(m.message, slice(labels, "discover"), info.Stats.Discovers)
+	setcp(m.message, slice(labels, "offer"), info.Stats.Offers)
+	setcp(m.message, slice(labels, "request"), info.Stats.Requests)
+	setcp(m.message, slice(labels, "ack"), info.Stats.Acks)
+	setcp(m.message, slice(labels, "nack"), info.Stats.Nacks)
+	setcp(m.message, slice(labels, "release"), info.Stats.Releases)
+	setcp(m.message, slice(labels, "decline"), info.Stats.Declines)
+	setcp(m.message, slice(labels, "inform"), info.Stats.Informs)
+	setcp(m.messageError, labels, info.Stats.Errors)
+
+	leases := int64(0)
+	for _, cPool := range info.Stats.IpPoolStats {
+		poolLabels := slice(labels, zero(cPool.DhcpIpPoolId))
+		allocated := zero(cPool.AllocatedNumber)
+		size := zero(cPool.PoolSize)
+		leases += allocated
+		set(m.poolLease, poolLabels, allocated)
+		set(m.poolSize, poolLabels, size)
+		if size > 0 {
+			set(m.poolUsage, poolLabels, float64(allocated)/float64(size))
+		}
+	}
+	set(m.lease, labels, leases)
+}
+
+func (m *DHCPMetrics) PopulateRelay(info api.DHCPRelayInfo) {
+	labels := []string{
+		info.ID,
+		info.Name,
+		info.ConnectivityPath,
+	}
+
+	for _, cServer := range info.ServerAddresses {
+		set(m.relay, slice(labels, cServer), 1)
+	}
+}
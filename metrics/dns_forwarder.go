@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// dns_forwarder_enable{gateway_id, id, name}
+// dns_forwarder_status{gateway_id, id, name, status} 1 == UP
+// dns_forwarder_query_total{gateway_id, id, name}
+// dns_forwarder_query_forwarded_total{gateway_id, id, name}
+// dns_forwarder_query_cached_total{gateway_id, id, name}
+// dns_forwarder_query_failed_total{gateway_id, id, name}
+// dns_forwarder_cache_entry{gateway_id, id, name}
+// dns_forwarder_cache_size{gateway_id, id, name}
+// dns_forwarder_upstream_query_succeeded_total{gateway_id, id, name, zone, upstream}
+// dns_forwarder_upstream_query_failed_total{gateway_id, id, name, zone, upstream}
+
+type DNSForwarderMetrics struct {
+	enable                 prometheus.GaugeVec
+	status                 prometheus.GaugeVec
+	query                  *CounterVec
+	queryForwarded         *CounterVec
+	queryCached            *CounterVec
+	queryFailed            *CounterVec
+	cacheEntry             prometheus.GaugeVec
+	cacheSize              prometheus.GaugeVec
+	upstreamQuerySucceeded *CounterVec
+	upstreamQueryFailed    *CounterVec
+}
+
+func NewDNSForwarderMetrics(namespace string) *DNSForwarderMetrics {
+	labels := []string{"gateway_id", "id", "name"}
+	return &DNSForwarderMetrics{
+		enable: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dns_forwarder_enable",
+				Help:      "Tells if dns forwarder is enabled, 1 is enabled",
+			}, labels),
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dns_forwarder_status",
+				Help:      "Gives status of dns forwarder, 1 is UP",
+			}, slice(labels, "status")),
+		query: NewCounterVec(
+			namespace,
+			"dns_forwarder_query",
+			"Total number of queries received by dns forwarder",
+			labels, false),
+		queryForwarded: NewCounterVec(
+			namespace,
+			"dns_forwarder_query_forwarded",
+			"Total number of queries forwarded by dns forwarder to upstream servers",
+			labels, false),
+		queryCached: NewCounterVec(
+			namespace,
+			"dns_forwarder_query_cached",
+			"Total number of queries answered from local cache by dns forwarder",
+			labels, false),
+		queryFailed: NewCounterVec(
+			namespace,
+			"dns_forwarder_query_failed",
+			"Total number of queries that dns forwarder failed to forward to any upstream server",
+			labels, false),
+		cacheEntry: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dns_forwarder_cache_entry",
+				Help:      "Number of entries in dns forwarder cache",
+			}, labels),
+		cacheSize: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "dns_forwarder_cache_size",
+				Help:      "Configured cache size of dns forwarder in kB",
+			}, labels),
+		upstreamQuerySucceeded: NewCounterVec(
+			namespace,
+			"dns_forwarder_upstream_query_succeeded",
+			"Total number of queries successfully forwarded to upstream server of dns forwarder zone",
+			slice(labels, "zone", "upstream"), false),
+		upstreamQueryFailed: NewCounterVec(
+			namespace,
+			"dns_forwarder_upstream_query_failed",
+			"Total number of queries that failed to be forwarded to upstream server of dns forwarder zone",
+			slice(labels, "zone", "upstream"), false),
+	}
+}
+
+func (m *DNSForwarderMetrics) Reset() {
+	m.enable.Reset()
+	m.status.Reset()
+	m.query.Reset()
+	m.queryForwarded.Reset()
+	m.queryCached.Reset()
+	m.queryFailed.Reset()
+	m.cacheEntry.Reset()
+	m.cacheSize.Reset()
+	m.upstreamQuerySucceeded.Reset()
+	m.upstreamQueryFailed.Reset()
+}
+
+func (m *DNSForwarderMetrics) Populate(info api.DNSForwarderInfo) {
+	labels := []string{
+		info.GatewayID,
+		zero(info.Config.Id),
+		zero(info.Config.DisplayName),
+	}
+
+	setb(m.enable, labels, info.Config.Enabled)
+	setv(m.status, labels, info.Status.Status, StatusUp)
+	setcp(m.query, labels, info.Stats.TotalQueries)
+	setcp(m.queryForwarded, labels, info.Stats.QueriesForwarded)
+	setcp(m.queryCached, labels, info.Stats.QueriesAnsweredLocally)
+	setp(m.cacheEntry, labels, info.Stats.CachedEntries)
+	setp(m.cacheSize, labels, info.Stats.ConfiguredCacheSize)
+
+	zones := []model.NsxTDNSForwarderZoneStatistics{}
+	if info.Stats.DefaultForwarderStatistics != nil {
+		zones = append(zones, *info.Stats.DefaultForwarderStatistics)
+	}
+	zones = append(zones, info.Stats.ConditionalForwarderStatistics...)
+
+	failed := int64(0)
+	for _, cZone := range zones {
+		zone := "default"
+		if len(cZone.DomainNames) != 0 {
+			zone = strings.Join(cZone.DomainNames, ",")
+		}
+		for _, cUpstream := range cZone.UpstreamStatistics {
+			upstreamLabels := slice(labels, zone, zero(cUpstream.UpstreamServer))
+			setcp(m.upstreamQuerySucceeded, upstreamLabels, cUpstream.QueriesSucceeded)
+			setcp(m.upstreamQueryFailed, upstreamLabels, cUpstream.QueriesFailed)
+			failed += zero(cUpstream.QueriesFailed)
+		}
+	}
+	setc(m.queryFailed, labels, failed)
+}
@@ -0,0 +1,419 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// edge_node_info{id, name, edge_cluster_id, index} 1
+// edge_node_status{id, name, status} 1 == UP
+// edge_node_version{id, name, version} 1
+// edge_node_cpu{id, name}
+// edge_node_cpu_usage{id, name, core, aggregation} %
+// edge_node_load{1,5,15}{id, name}
+// edge_node_mem_{size,used,cache}{id, name}
+// edge_node_swap_{size,used}{id, name}
+// edge_node_mem_usage{id, name, kind} %
+// edge_node_mem_pool_usage{id, name, pool, description} %
+// edge_node_fs_{size,used}{id, name, type, mount}
+// edge_node_uptime{id, name}
+// edge_node_flow_cache_{hit,miss}_total{id, name, cache}
+// edge_node_flow_cache_hit_rate{id, name, cache} %
+// edge_node_lb_usage_{virtual_server,pool,pool_member,pool_member_max}{id, name}
+// edge_node_lb_usage_percent{id, name}
+// edge_node_lb_usage_severity{id, name, severity} 1 == GREEN
+// edge_node_lb_credit{id, name}
+// edge_node_lb_credit_max{id, name}
+// edge_cluster_info{id, name, deployment_type, member_node_type} 1
+// edge_cluster_member{id, name}
+// edge_cluster_member_status{id, name, index, transport_node_id, deployment_type, status} 1 == UP
+// edge_cluster_member_sr{id, name, index, transport_node_id, tier}
+
+type EdgeNodeMetrics struct {
+	info        prometheus.GaugeVec
+	status      prometheus.GaugeVec
+	version     prometheus.GaugeVec
+	cpu         prometheus.GaugeVec
+	cpuUsage    prometheus.GaugeVec
+	load1       prometheus.GaugeVec
+	load5       prometheus.GaugeVec
+	load15      prometheus.GaugeVec
+	memTotal    prometheus.GaugeVec
+	memUsed     prometheus.GaugeVec
+	memCache    prometheus.GaugeVec
+	swapTotal   prometheus.GaugeVec
+	swapUsed    prometheus.GaugeVec
+	memUsage    prometheus.GaugeVec
+	memPool     prometheus.GaugeVec
+	fsTotal     prometheus.GaugeVec
+	fsUsed      prometheus.GaugeVec
+	uptime      prometheus.GaugeVec
+	flowHit     *CounterVec
+	flowMiss    *CounterVec
+	flowRate    prometheus.GaugeVec
+	lbUsage     *LBUsageMetrics
+	lbCredit    prometheus.GaugeVec
+	lbCreditMax prometheus.GaugeVec
+}
+
+func NewEdgeNodeMetrics(namespace string) *EdgeNodeMetrics {
+	labels := []string{"id", "name"}
+	return &EdgeNodeMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_info",
+				Help:      "Give edge cluster membership as label about edge node, value is always 1",
+			}, slice(labels, "edge_cluster_id", "index")),
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_status",
+				Help:      "Gives status of edge transport node, 1 is UP",
+			}, slice(labels, "status")),
+		version: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_version",
+				Help:      "Edge node current version, value always 1",
+			}, slice(labels, "version")),
+		cpu: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_cpu",
+				Help:      "Number of CPU core of edge node",
+			}, labels),
+		cpuUsage: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_cpu_usage",
+				Help:      "CPU usage percentage of edge node datapath or service cores, average or highest core",
+			}, slice(labels, "core", "aggregation")),
+		memUsage: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_mem_usage",
+				Help:      "Memory usage percentage of edge node by kind (system, swap, cache, datapath, datapath_heap)",
+			}, slice(labels, "kind")),
+		memPool: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_mem_pool_usage",
+				Help:      "Usage percentage of edge node datapath memory pool",
+			}, slice(labels, "pool", "description")),
+		load1: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_load1",
+				Help:      "Current load average of edge node (load 1 minute)",
+			}, labels),
+		load5: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_load5",
+				Help:      "Current load average of edge node (load 5 minutes)",
+			}, labels),
+		load15: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_load15",
+				Help:      "Current load average of edge node (load 15 minutes)",
+			}, labels),
+		memTotal: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_mem_size",
+				Help:      "Total available memory of edge node in kB",
+			}, labels),
+		memUsed: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_mem_used",
+				Help:      "Used memory of edge node in kB",
+			}, labels),
+		memCache: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_mem_cache",
+				Help:      "Cached memory of edge node in kB",
+			}, labels),
+		swapTotal: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_swap_size",
+				Help:      "Total swap space of edge node in kB",
+			}, labels),
+		swapUsed: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_swap_used",
+				Help:      "Used swap space of edge node in kB",
+			}, labels),
+		fsTotal: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_fs_size",
+				Help:      "Total filesystem space of edge node in kB",
+			}, slice(labels, "type", "mount")),
+		fsUsed: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_fs_used",
+				Help:      "Used filesystem space of edge node in kB",
+			}, slice(labels, "type", "mount")),
+		uptime: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_uptime",
+				Help:      "Uptime of edge node expressed in millisecond since start",
+			}, labels),
+		flowHit: NewCounterVec(
+			namespace,
+			"edge_node_flow_cache_hit",
+			"Number of datapath flow cache hits of edge node by cache (micro, mega)",
+			slice(labels, "cache"), false),
+		flowMiss: NewCounterVec(
+			namespace,
+			"edge_node_flow_cache_miss",
+			"Number of datapath flow cache misses of edge node by cache (micro, mega)",
+			slice(labels, "cache"), false),
+		flowRate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_flow_cache_hit_rate",
+				Help:      "Datapath flow cache hit percentage of edge node by cache (micro, mega) since datapath start",
+			}, slice(labels, "cache")),
+		lbUsage: NewLBUsageMetrics(namespace, "edge_node_lb", labels),
+		lbCredit: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_lb_credit",
+				Help:      "Load balancer credits consumed by load balancers on edge node",
+			}, labels),
+		lbCreditMax: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_node_lb_credit_max",
+				Help:      "Load balancer credits capacity of edge node",
+			}, labels),
+	}
+}
+
+func (m *EdgeNodeMetrics) Reset() {
+	m.info.Reset()
+	m.status.Reset()
+	m.version.Reset()
+	m.cpu.Reset()
+	m.cpuUsage.Reset()
+	m.load1.Reset()
+	m.load5.Reset()
+	m.load15.Reset()
+	m.memTotal.Reset()
+	m.memUsed.Reset()
+	m.memCache.Reset()
+	m.swapTotal.Reset()
+	m.swapUsed.Reset()
+	m.memUsage.Reset()
+	m.memPool.Reset()
+	m.fsTotal.Reset()
+	m.fsUsed.Reset()
+	m.uptime.Reset()
+	m.flowHit.Reset()
+	m.flowMiss.Reset()
+	m.flowRate.Reset()
+	m.lbUsage.Reset()
+	m.lbCredit.Reset()
+	m.lbCreditMax.Reset()
+}
+
+func (m *EdgeNodeMetrics) Populate(info api.EdgeNodeInfo) {
+	labels := []string{
+		info.Node.Id,
+		info.Node.DisplayName,
+	}
+
+	set(m.info, slice(labels, info.ClusterID, info.MemberIndex), 1)
+	set(m.status, slice(labels, info.Status.Status), statusToValue(info.Status.Status, StatusUp))
+	set(m.version, slice(labels, info.NodeStatus.SoftwareVersion), 1)
+	if info.FlowCache != nil {
+		m.populateFlowCache(labels, *info.FlowCache)
+	}
+
+	system := info.NodeStatus.SystemStatus
+	if system == nil {
+		return
+	}
+	set(m.cpu, labels, system.CpuCores)
+	if len(system.LoadAverage) == 3 {
+		set(m.load1, labels, system.LoadAverage[0])
+		set(m.load5, labels, system.LoadAverage[1])
+		set(m.load15, labels, system.LoadAverage[2])
+	}
+	set(m.memTotal, labels, system.MemTotal)
+	set(m.memUsed, labels, system.MemUsed)
+	set(m.memCache, labels, system.MemCache)
+	set(m.swapTotal, labels, system.SwapTotal)
+	set(m.swapUsed, labels, system.SwapUsed)
+	for _, cFS := range system.FileSystems {
+		fsLabels := slice(labels, cFS.Type_, cFS.Mount)
+		set(m.fsTotal, fsLabels, cFS.Total)
+		set(m.fsUsed, fsLabels, cFS.Used)
+	}
+	set(m.uptime, labels, system.Uptime)
+
+	if cpu := system.CPUUsage; cpu != nil {
+		setp(m.cpuUsage, slice(labels, "datapath", "avg"), cpu.AvgDatapath)
+		setp(m.cpuUsage, slice(labels, "datapath", "highest"), cpu.HighestDatapath)
+		setp(m.cpuUsage, slice(labels, "service", "avg"), cpu.AvgService)
+		setp(m.cpuUsage, slice(labels, "service", "highest"), cpu.HighestService)
+	}
+	if mem := system.MemUsage; mem != nil {
+		setp(m.memUsage, slice(labels, "system"), mem.SystemUsage)
+		setp(m.memUsage, slice(labels, "swap"), mem.SwapUsage)
+		setp(m.memUsage, slice(labels, "cache"), mem.CacheUsage)
+		setp(m.memUsage, slice(labels, "datapath"), mem.DatapathUsage)
+		if mem.Datapath != nil {
+			setp(m.memUsage, slice(labels, "datapath_heap"), mem.Datapath.HeapUsage)
+			for _, cPool := range mem.Datapath.PoolsUsage {
+				setp(m.memPool, slice(labels, cPool.Name, cPool.Description), cPool.Usage)
+			}
+		}
+	}
+}
+
+// populateFlowCache - flow cache statistics are summed over datapath cores, hit rate
+// is only set once the cache has been looked up
+func (m *EdgeNodeMetrics) populateFlowCache(labels []string, stats api.EdgeFlowCacheStats) {
+	microHit, microMiss, megaHit, megaMiss := 0.0, 0.0, 0.0, 0.0
+	for _, cCore := range stats.Cores {
+		microHit += zero(cCore.MicroHit)
+		microMiss += zero(cCore.MicroMiss)
+		megaHit += zero(cCore.MegaHit)
+		megaMiss += zero(cCore.MegaMiss)
+	}
+	m.setFlowCache(slice(labels, "micro"), microHit, microMiss)
+	m.setFlowCache(slice(labels, "mega"), megaHit, megaMiss)
+}
+
+func (m *EdgeNodeMetrics) setFlowCache(labels []string, hit float64, miss float64) {
+	setc(m.flowHit, labels, hit)
+	setc(m.flowMiss, labels, miss)
+	if hit+miss != 0 {
+		set(m.flowRate, labels, 100*hit/(hit+miss))
+	}
+}
+
+// PopulateLBUsage - sets load balancer capacity usage of edge node, edge nodes are matched
+// on the last element of usage node path
+func (m *EdgeNodeMetrics) PopulateLBUsage(info api.EdgeNodeInfo, usages []model.LBEdgeNodeUsage) {
+	labels := []string{
+		info.Node.Id,
+		info.Node.DisplayName,
+	}
+
+	for _, cUsage := range usages {
+		if api.PathToID(zero(cUsage.NodePath)) != info.Node.Id {
+			continue
+		}
+		setp(m.lbUsage.vs, labels, cUsage.CurrentVirtualServerCount)
+		setp(m.lbUsage.pool, labels, cUsage.CurrentPoolCount)
+		setp(m.lbUsage.poolMember, labels, cUsage.CurrentPoolMemberCount)
+		setp(m.lbUsage.poolMemberMax, labels, cUsage.PoolMemberCapacity)
+		setp(m.lbUsage.percent, labels, cUsage.UsagePercentage)
+		setv(m.lbUsage.severity, labels, cUsage.Severity, StatusGreen)
+		setp(m.lbCredit, labels, cUsage.CurrentLoadBalancerCredits)
+		setp(m.lbCreditMax, labels, cUsage.LoadBalancerCreditCapacity)
+	}
+}
+
+type EdgeClusterMetrics struct {
+	info         prometheus.GaugeVec
+	member       prometheus.GaugeVec
+	memberStatus prometheus.GaugeVec
+	memberSR     prometheus.GaugeVec
+}
+
+func NewEdgeClusterMetrics(namespace string) *EdgeClusterMetrics {
+	labels := []string{"id", "name"}
+	memberLabels := slice(labels, "index", "transport_node_id")
+	return &EdgeClusterMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_cluster_info",
+				Help:      "Give informations as label about edge cluster, value is always 1",
+			}, slice(labels, "deployment_type", "member_node_type")),
+		member: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_cluster_member",
+				Help:      "Number of members in edge cluster",
+			}, labels),
+		memberStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_cluster_member_status",
+				Help:      "Gives connectivity status of edge cluster member, 1 is UP",
+			}, slice(memberLabels, "deployment_type", "status")),
+		memberSR: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "edge_cluster_member_sr",
+				Help:      "Number of gateway service routers placed on edge cluster member",
+			}, slice(memberLabels, "tier")),
+	}
+}
+
+func (m *EdgeClusterMetrics) Reset() {
+	m.info.Reset()
+	m.member.Reset()
+	m.memberStatus.Reset()
+	m.memberSR.Reset()
+}
+
+// Populate - service routers are matched to members from the edge path of tier gateways
+// per node status, ie: /infra/sites/<site>/enforcement-points/<ep>/edge-clusters/<id>/edge-nodes/<index>
+func (m *EdgeClusterMetrics) Populate(
+	info api.EdgeClusterInfo,
+	t0s []*model.LogicalRouterStatus,
+	t1s []*model.LogicalRouterStatus,
+) {
+	labels := []string{
+		info.Cluster.Id,
+		info.Cluster.DisplayName,
+	}
+
+	set(m.info, slice(labels, info.Cluster.DeploymentType, info.Cluster.MemberNodeType), 1)
+	set(m.member, labels, len(info.Members))
+
+	for _, cMember := range info.Members {
+		index := fmt.Sprintf("%d", cMember.Member.MemberIndex)
+		memberLabels := slice(labels, index, cMember.Member.TransportNodeId)
+		status := cMember.Status.Status
+		set(m.memberStatus, slice(memberLabels, cMember.DeploymentType, status), statusToValue(status, StatusUp))
+
+		suffix := fmt.Sprintf("/edge-clusters/%s/edge-nodes/%s", info.Cluster.Id, index)
+		set(m.memberSR, slice(memberLabels, "tier0"), countSR(t0s, suffix))
+		set(m.memberSR, slice(memberLabels, "tier1"), countSR(t1s, suffix))
+	}
+}
+
+func countSR(statuses []*model.LogicalRouterStatus, suffix string) int {
+	count := 0
+	for _, cStatus := range statuses {
+		if cStatus == nil {
+			continue
+		}
+		for _, cNode := range cStatus.PerNodeStatus {
+			if strings.HasSuffix(zero(cNode.EdgePath), suffix) {
+				count++
+			}
+		}
+	}
+	return count
+}
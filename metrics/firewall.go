@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// firewall_policy_info{domain, id, name, category, stateful} 1
+// firewall_policy_rule{domain, id, name}
+// firewall_policy_rule_max{domain, id, name}
+// firewall_rule_info{domain, policy_id, id, name, action, disabled} 1
+// firewall_rule_hit_total{domain, policy_id, id, name}
+// firewall_rule_packet_total{domain, policy_id, id, name}
+// firewall_rule_byte_total{domain, policy_id, id, name}
+// firewall_rule_session_total{domain, policy_id, id, name}
+
+type FirewallMetrics struct {
+	policyInfo prometheus.GaugeVec
+	policyRule *TotalMetrics
+	ruleInfo   prometheus.GaugeVec
+	hit        *CounterVec
+	packet     *CounterVec
+	byte       *CounterVec
+	session    *CounterVec
+}
+
+func NewFirewallMetrics(namespace string) *FirewallMetrics {
+	policyLabels := []string{"domain", "id", "name"}
+	ruleLabels := []string{"domain", "policy_id", "id", "name"}
+	return &FirewallMetrics{
+		policyRule: NewTotalMetrics(namespace, "firewall_policy", "rule", policyLabels),
+		policyInfo: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "firewall_policy_info",
+				Help:      "Give informations as label about firewall security policy, value is always 1",
+			}, slice(policyLabels, "category", "stateful")),
+		ruleInfo: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "firewall_rule_info",
+				Help:      "Give informations as label about firewall rule, value is always 1",
+			}, slice(ruleLabels, "action", "disabled")),
+		hit: NewCounterVec(
+			namespace,
+			"firewall_rule_hit",
+			"Aggregated number of hits received by firewall rule",
+			ruleLabels, false),
+		packet: NewCounterVec(
+			namespace,
+			"firewall_rule_packet",
+			"Aggregated number of packets processed by firewall rule",
+			ruleLabels, false),
+		byte: NewCounterVec(
+			namespace,
+			"firewall_rule_byte",
+			"Aggregated number of bytes processed by firewall rule",
+			ruleLabels, false),
+		session: NewCounterVec(
+			namespace,
+			"firewall_rule_session",
+			"Aggregated number of sessions processed by firewall rule",
+			ruleLabels, false),
+	}
+}
+
+func (f *FirewallMetrics) Reset() {
+	f.policyInfo.Reset()
+	f.policyRule.Reset()
+	f.ruleInfo.Reset()
+	f.hit.Reset()
+	f.packet.Reset()
+	f.byte.Reset()
+	f.session.Reset()
+}
+
+func (f *FirewallMetrics) Populate(info api.SecurityPolicyInfo) {
+	domain := zero(info.Domain.Id)
+	labels := []string{
+		domain,
+		zero(info.Config.Id),
+		zero(info.Config.DisplayName),
+	}
+	infoLabels := slice(
+		labels,
+		zero(info.Config.Category),
+		fmt.Sprintf("%t", zero(info.Config.Stateful)),
+	)
+
+	set(f.policyInfo, infoLabels, 1)
+	setp(f.policyRule.total, labels, info.Config.RuleCount)
+	set(f.policyRule.max, labels, info.MaxRules)
+
+	rules := map[string]model.Rule{}
+	for _, cRule := range info.Rules {
+		rules[zero(cRule.Path)] = cRule
+	}
+
+	for _, cStat := range info.Stats {
+		path := zero(cStat.Rule)
+		rule := rules[path]
+		ruleLabels := []string{
+			domain,
+			zero(info.Config.Id),
+			api.PathToID(path),
+			zero(rule.DisplayName),
+		}
+		ruleInfoLabels := slice(
+			ruleLabels,
+			zero(rule.Action),
+			fmt.Sprintf("%t", zero(rule.Disabled)),
+		)
+		set(f.ruleInfo, ruleInfoLabels, 1)
+		setcp(f.hit, ruleLabels, cStat.HitCount)
+		setcp(f.packet, ruleLabels, cStat.PacketCount)
+		setcp(f.byte, ruleLabels, cStat.ByteCount)
+		setcp(f.session, ruleLabels, cStat.SessionCount)
+	}
+}
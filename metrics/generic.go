@@ -3,6 +3,7 @@ package metrics
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -12,7 +13,7 @@ import (
 type SessionMetrics struct {
 	rate    prometheus.GaugeVec
 	current prometheus.GaugeVec
-	total   prometheus.GaugeVec
+	total   *CounterVec
 	max     prometheus.GaugeVec
 }
 
@@ -22,8 +23,10 @@ type NetworkMetrics struct {
 	outPacket    *RateMetrics
 	inByte       *RateMetrics
 	outByte      *RateMetrics
-	session      *RateMetrics
-	sessionTotal *TotalMetrics
+	session      prometheus.GaugeVec
+	sessionRate  prometheus.GaugeVec
+	sessionTotal *CounterVec
+	sessionMax   prometheus.GaugeVec
 }
 
 type TotalMetrics struct {
@@ -32,8 +35,79 @@ type TotalMetrics struct {
 }
 
 type RateMetrics struct {
-	current prometheus.GaugeVec
-	rate    prometheus.GaugeVec
+	total *CounterVec
+	rate  prometheus.GaugeVec
+}
+
+// CounterVec - cumulative NSX statistic exported as a prometheus counter
+//
+// NSX gives the absolute value of its counters so they are published as const
+// metrics named with a _total suffix. When legacy is true and the given name
+// doesn't already end with _total, the value is also published with the former
+// gauge name, legacy must only be set for statistics that were exported as gauges
+// before.
+type CounterVec struct {
+	desc   *prometheus.Desc
+	legacy *prometheus.GaugeVec
+	mutex  sync.RWMutex
+	values map[string]counterValue
+}
+
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+func NewCounterVec(namespace string, name string, help string, labels []string, legacy bool) *CounterVec {
+	c := &CounterVec{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", strings.TrimSuffix(name, "_total")+"_total"),
+			help, labels, nil),
+		values: map[string]counterValue{},
+	}
+	if legacy && !strings.HasSuffix(name, "_total") {
+		c.legacy = promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      name,
+				Help:      help,
+			}, labels)
+	}
+	prometheus.MustRegister(c)
+	return c
+}
+
+func (c *CounterVec) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *CounterVec) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, cVal := range c.values {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, cVal.value, cVal.labels...)
+	}
+}
+
+func (c *CounterVec) Set(labels []string, value float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values[strings.Join(labels, "\xff")] = counterValue{
+		labels: slice(labels),
+		value:  value,
+	}
+	if c.legacy != nil {
+		c.legacy.WithLabelValues(labels...).Set(value)
+	}
+}
+
+func (c *CounterVec) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values = map[string]counterValue{}
+	if c.legacy != nil {
+		c.legacy.Reset()
+	}
 }
 
 func NewTotalMetrics(namespace string, object string, kind string, labels []string) *TotalMetrics {
@@ -41,8 +115,8 @@ func NewTotalMetrics(namespace string, object string, kind string, labels []stri
 		total: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
-				Name:      fmt.Sprintf("%s_%s_total", object, kind),
-				Help:      fmt.Sprintf("Total number of %s in %s", kind, strings.ReplaceAll(object, "_", " ")),
+				Name:      fmt.Sprintf("%s_%s", object, kind),
+				Help:      fmt.Sprintf("Current number of %s in %s", kind, strings.ReplaceAll(object, "_", " ")),
 			}, labels),
 		max: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -58,14 +132,13 @@ func (v *TotalMetrics) Reset() {
 	v.max.Reset()
 }
 
-func NewRateMetrics(namespace string, object string, kind string, labels []string) *RateMetrics {
+func NewRateMetrics(namespace string, object string, kind string, labels []string, legacy bool) *RateMetrics {
 	return &RateMetrics{
-		current: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      fmt.Sprintf("%s_%s", object, kind),
-				Help:      fmt.Sprintf("Current number of %s in %s", kind, strings.ReplaceAll(object, "_", " ")),
-			}, labels),
+		total: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_%s", object, kind),
+			fmt.Sprintf("Total number of %s in %s", kind, strings.ReplaceAll(object, "_", " ")),
+			labels, legacy),
 		rate: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -76,7 +149,7 @@ func NewRateMetrics(namespace string, object string, kind string, labels []strin
 }
 
 func (v *RateMetrics) Reset() {
-	v.current.Reset()
+	v.total.Reset()
 	v.rate.Reset()
 }
 
@@ -94,12 +167,11 @@ func NewSessionMetrics(namespace string, object string, kind string, labels []st
 				Name:      fmt.Sprintf("%s_session_%s_current", object, kind),
 				Help:      fmt.Sprintf("Current number of %s session for %s", kind, object),
 			}, labels),
-		total: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      fmt.Sprintf("%s_session_%s_total", object, kind),
-				Help:      fmt.Sprintf("Total number of %s session for %s", kind, object),
-			}, labels),
+		total: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_session_%s_total", object, kind),
+			fmt.Sprintf("Total number of %s session for %s", kind, object),
+			labels, false),
 		max: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -116,15 +188,37 @@ func (s *SessionMetrics) Reset() {
 	s.max.Reset()
 }
 
-func NewNetworkMetrics(namespace string, object string, labels []string) NetworkMetrics {
+func NewNetworkMetrics(namespace string, object string, labels []string, legacy bool) NetworkMetrics {
+	name := strings.ReplaceAll(object, "_", " ")
 	return NetworkMetrics{
-		http:         NewRateMetrics(namespace, object, "http_request", labels),
-		inByte:       NewRateMetrics(namespace, object, "in_byte", labels),
-		inPacket:     NewRateMetrics(namespace, object, "in_packet", labels),
-		outByte:      NewRateMetrics(namespace, object, "out_byte", labels),
-		outPacket:    NewRateMetrics(namespace, object, "out_packet", labels),
-		session:      NewRateMetrics(namespace, object, "session", labels),
-		sessionTotal: NewTotalMetrics(namespace, object, "session", labels),
+		http:      NewRateMetrics(namespace, object, "http_request", labels, legacy),
+		inByte:    NewRateMetrics(namespace, object, "in_byte", labels, legacy),
+		inPacket:  NewRateMetrics(namespace, object, "in_packet", labels, legacy),
+		outByte:   NewRateMetrics(namespace, object, "out_byte", labels, legacy),
+		outPacket: NewRateMetrics(namespace, object, "out_packet", labels, legacy),
+		session: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_session", object),
+				Help:      fmt.Sprintf("Current number of session in %s", name),
+			}, labels),
+		sessionRate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_session_rate", object),
+				Help:      fmt.Sprintf("Number of session per second in %s", name),
+			}, labels),
+		sessionTotal: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_session_total", object),
+			fmt.Sprintf("Total number of session in %s", name),
+			labels, legacy),
+		sessionMax: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_session_max", object),
+				Help:      fmt.Sprintf("Maximum number of session in %s", name),
+			}, labels),
 	}
 }
 
@@ -135,22 +229,146 @@ func (n *NetworkMetrics) Reset() {
 	n.outByte.Reset()
 	n.outPacket.Reset()
 	n.session.Reset()
+	n.sessionRate.Reset()
 	n.sessionTotal.Reset()
+	n.sessionMax.Reset()
 }
 
 func (n *NetworkMetrics) Populate(labels []string, c *model.LBStatisticsCounter) {
-	setp(n.http.current, labels, c.HttpRequests)
+	setcp(n.http.total, labels, c.HttpRequests)
 	setp(n.http.rate, labels, c.HttpRequestRate)
-	setp(n.inPacket.current, labels, c.PacketsIn)
+	setcp(n.inPacket.total, labels, c.PacketsIn)
 	setp(n.inPacket.rate, labels, c.PacketsInRate)
-	setp(n.outPacket.current, labels, c.PacketsOut)
+	setcp(n.outPacket.total, labels, c.PacketsOut)
 	setp(n.outPacket.rate, labels, c.PacketsOutRate)
-	setp(n.inByte.current, labels, c.BytesIn)
+	setcp(n.inByte.total, labels, c.BytesIn)
 	setp(n.inByte.rate, labels, c.BytesInRate)
-	setp(n.outByte.current, labels, c.BytesOut)
+	setcp(n.outByte.total, labels, c.BytesOut)
 	setp(n.outByte.rate, labels, c.BytesOutRate)
-	setp(n.session.current, labels, c.CurrentSessions)
-	setp(n.session.rate, labels, c.CurrentSessionRate)
-	setp(n.sessionTotal.max, labels, c.MaxSessions)
-	setp(n.sessionTotal.total, labels, c.TotalSessions)
+	setp(n.session, labels, c.CurrentSessions)
+	setp(n.sessionRate, labels, c.CurrentSessionRate)
+	setp(n.sessionMax, labels, c.MaxSessions)
+	setcp(n.sessionTotal, labels, c.TotalSessions)
+}
+
+// TrafficMetrics - byte and packet counters of a network object, dropped packet counters
+// are only registered when dropped is true as some objects don't report them
+type TrafficMetrics struct {
+	inByte     *CounterVec
+	outByte    *CounterVec
+	inPacket   *CounterVec
+	outPacket  *CounterVec
+	inDropped  *CounterVec
+	outDropped *CounterVec
+}
+
+func NewTrafficMetrics(namespace string, object string, labels []string, dropped bool) *TrafficMetrics {
+	name := strings.ReplaceAll(object, "_", " ")
+	res := &TrafficMetrics{
+		inByte: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_in_byte", object),
+			fmt.Sprintf("Number of bytes received by %s", name),
+			labels, false),
+		outByte: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_out_byte", object),
+			fmt.Sprintf("Number of bytes sent by %s", name),
+			labels, false),
+		inPacket: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_in_packet", object),
+			fmt.Sprintf("Number of packets received by %s", name),
+			labels, false),
+		outPacket: NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_out_packet", object),
+			fmt.Sprintf("Number of packets sent by %s", name),
+			labels, false),
+	}
+	if dropped {
+		res.inDropped = NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_in_dropped", object),
+			fmt.Sprintf("Number of received packets dropped by %s", name),
+			labels, false)
+		res.outDropped = NewCounterVec(
+			namespace,
+			fmt.Sprintf("%s_out_dropped", object),
+			fmt.Sprintf("Number of sent packets dropped by %s", name),
+			labels, false)
+	}
+	return res
+}
+
+func (t *TrafficMetrics) Reset() {
+	t.inByte.Reset()
+	t.outByte.Reset()
+	t.inPacket.Reset()
+	t.outPacket.Reset()
+	if t.inDropped != nil {
+		t.inDropped.Reset()
+		t.outDropped.Reset()
+	}
+}
+
+// LBUsageMetrics - load balancer capacity usage, shared by load balancer services and edge nodes,
+// virtual server and pool capacities are only given for load balancer services
+type LBUsageMetrics struct {
+	vs            prometheus.GaugeVec
+	pool          prometheus.GaugeVec
+	poolMember    prometheus.GaugeVec
+	poolMemberMax prometheus.GaugeVec
+	percent       prometheus.GaugeVec
+	severity      prometheus.GaugeVec
+}
+
+func NewLBUsageMetrics(namespace string, object string, labels []string) *LBUsageMetrics {
+	return &LBUsageMetrics{
+		vs: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_usage_virtual_server", object),
+				Help:      fmt.Sprintf("Current number of virtual servers in %s", object),
+			}, labels),
+		pool: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_usage_pool", object),
+				Help:      fmt.Sprintf("Current number of pools in %s", object),
+			}, labels),
+		poolMember: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_usage_pool_member", object),
+				Help:      fmt.Sprintf("Current number of pool members in %s", object),
+			}, labels),
+		poolMemberMax: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_usage_pool_member_max", object),
+				Help:      fmt.Sprintf("Maximum number of pool members in %s", object),
+			}, labels),
+		percent: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_usage_percent", object),
+				Help:      fmt.Sprintf("Load balancer capacity usage percentage of %s", object),
+			}, labels),
+		severity: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_usage_severity", object),
+				Help:      fmt.Sprintf("Load balancer capacity usage severity of %s, 1 is GREEN", object),
+			}, slice(labels, "severity")),
+	}
+}
+
+func (u *LBUsageMetrics) Reset() {
+	u.vs.Reset()
+	u.pool.Reset()
+	u.poolMember.Reset()
+	u.poolMemberMax.Reset()
+	u.percent.Reset()
+	u.severity.Reset()
 }
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// host_node_status{id, name, status} 1 == UP
+// host_node_mgmt_connection_status{id, name, status} 1 == UP
+// host_node_control_connection_status{id, name, status} 1 == UP
+// host_node_pnic_status{id, name, status} 1 == UP
+// host_node_pnic{id, name, status}
+// host_node_tunnel{id, name, remote_node_id, status}
+// host_node_tunnel_bfd_status{id, name, tunnel, remote_node_id, local_ip, remote_ip, status, diagnostic} 1 == UP
+
+type HostNodeMetrics struct {
+	status                  prometheus.GaugeVec
+	mgmtConnectionStatus    prometheus.GaugeVec
+	controlConnectionStatus prometheus.GaugeVec
+	pnicStatus              prometheus.GaugeVec
+	pnic                    prometheus.GaugeVec
+	tunnel                  prometheus.GaugeVec
+	tunnelBfdStatus         prometheus.GaugeVec
+}
+
+func NewHostNodeMetrics(namespace string) *HostNodeMetrics {
+	labels := []string{"id", "name"}
+	return &HostNodeMetrics{
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_status",
+				Help:      "Gives status of host transport node, 1 is UP",
+			}, slice(labels, "status")),
+		mgmtConnectionStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_mgmt_connection_status",
+				Help:      "Gives status of management plane connection of host transport node, 1 is UP",
+			}, slice(labels, "status")),
+		controlConnectionStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_control_connection_status",
+				Help:      "Gives status of control plane connections of host transport node, 1 is UP",
+			}, slice(labels, "status")),
+		pnicStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_pnic_status",
+				Help:      "Gives aggregated status of physical nics of host transport node, 1 is UP",
+			}, slice(labels, "status")),
+		pnic: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_pnic",
+				Help:      "Number of physical nics of host transport node by status",
+			}, slice(labels, "status")),
+		tunnel: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_tunnel",
+				Help:      "Number of tunnels of host transport node by remote node and status",
+			}, slice(labels, "remote_node_id", "status")),
+		tunnelBfdStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "host_node_tunnel_bfd_status",
+				Help:      "Gives BFD status of host transport node tunnel, 1 is UP",
+			}, slice(labels, "tunnel", "remote_node_id", "local_ip", "remote_ip", "status", "diagnostic")),
+	}
+}
+
+func (m *HostNodeMetrics) Reset() {
+	m.status.Reset()
+	m.mgmtConnectionStatus.Reset()
+	m.controlConnectionStatus.Reset()
+	m.pnicStatus.Reset()
+	m.pnic.Reset()
+	m.tunnel.Reset()
+	m.tunnelBfdStatus.Reset()
+}
+
+func (m *HostNodeMetrics) Populate(info api.HostNodeInfo) {
+	labels := []string{
+		info.Node.Id,
+		info.Node.DisplayName,
+	}
+
+	status := info.Status
+	set(m.status, slice(labels, status.Status), statusToValue(status.Status, StatusUp))
+	set(m.mgmtConnectionStatus, slice(labels, status.MgmtConnectionStatus), statusToValue(status.MgmtConnectionStatus, StatusUp))
+	if c := status.ControlConnectionStatus; c != nil {
+		set(m.controlConnectionStatus, slice(labels, c.Status), statusToValue(c.Status, StatusUp))
+	}
+	if c := status.PnicStatus; c != nil {
+		set(m.pnicStatus, slice(labels, c.Status), statusToValue(c.Status, StatusUp))
+		set(m.pnic, slice(labels, "UP"), c.UpCount)
+		set(m.pnic, slice(labels, "DEGRADED"), c.DegradedCount)
+		set(m.pnic, slice(labels, "DOWN"), c.DownCount)
+	}
+
+	tunnels := map[string]map[string]int{}
+	for _, cTunnel := range info.Tunnels {
+		if _, ok := tunnels[cTunnel.RemoteNodeId]; !ok {
+			tunnels[cTunnel.RemoteNodeId] = map[string]int{"UP": 0, "DOWN": 0}
+		}
+		tunnels[cTunnel.RemoteNodeId][cTunnel.Status]++
+
+		if cTunnel.Bfd != nil {
+			bfdLabels := slice(
+				labels,
+				cTunnel.Name,
+				cTunnel.RemoteNodeId,
+				cTunnel.LocalIp,
+				cTunnel.RemoteIp,
+				cTunnel.Bfd.State,
+				cTunnel.Bfd.Diagnostic,
+			)
+			set(m.tunnelBfdStatus, bfdLabels, statusToValue(cTunnel.Bfd.State, StatusUp))
+		}
+	}
+	for cRemote, cStatuses := range tunnels {
+		for cStatus, cCount := range cStatuses {
+			set(m.tunnel, slice(labels, cRemote, cStatus), cCount)
+		}
+	}
+}
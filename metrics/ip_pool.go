@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ip_pool_size{id, name}
+// ip_pool_allocated{id, name}
+// ip_pool_free{id, name}
+// ip_pool_subnet_size{id, name, cidr}
+// ip_pool_subnet_allocated{id, name, cidr}
+// ip_pool_subnet_free{id, name, cidr}
+// ip_block_size{id, name, cidr}
+// ip_block_allocated{id, name, cidr}
+// ip_block_usage{id, name, cidr}
+
+type IPPoolMetrics struct {
+	total           prometheus.GaugeVec
+	allocated       prometheus.GaugeVec
+	free            prometheus.GaugeVec
+	subnetTotal     prometheus.GaugeVec
+	subnetAllocated prometheus.GaugeVec
+	subnetFree      prometheus.GaugeVec
+}
+
+func NewIPPoolMetrics(namespace string) *IPPoolMetrics {
+	labels := []string{"id", "name"}
+	return &IPPoolMetrics{
+		total: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_pool_size",
+				Help:      "Number of addresses in ip pool",
+			}, labels),
+		allocated: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_pool_allocated",
+				Help:      "Number of allocated addresses in ip pool",
+			}, labels),
+		free: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_pool_free",
+				Help:      "Number of free addresses in ip pool",
+			}, labels),
+		subnetTotal: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_pool_subnet_size",
+				Help:      "Number of addresses in allocation ranges of ip pool subnet",
+			}, slice(labels, "cidr")),
+		subnetAllocated: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_pool_subnet_allocated",
+				Help:      "Number of allocated addresses in ip pool subnet",
+			}, slice(labels, "cidr")),
+		subnetFree: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_pool_subnet_free",
+				Help:      "Number of free addresses in ip pool subnet",
+			}, slice(labels, "cidr")),
+	}
+}
+
+func (m *IPPoolMetrics) Reset() {
+	m.total.Reset()
+	m.allocated.Reset()
+	m.free.Reset()
+	m.subnetTotal.Reset()
+	m.subnetAllocated.Reset()
+	m.subnetFree.Reset()
+}
+
+func (m *IPPoolMetrics) Populate(info api.IPPoolInfo) {
+	labels := []string{
+		info.ID,
+		info.Name,
+	}
+
+	if info.Usage != nil {
+		setp(m.total, labels, info.Usage.TotalIps)
+		setp(m.allocated, labels, info.Usage.AllocatedIpAllocations)
+		setp(m.free, labels, info.Usage.AvailableIps)
+	}
+
+	for _, cSubnet := range info.Subnets {
+		sLabels := slice(labels, cSubnet.CIDR)
+		set(m.subnetTotal, sLabels, cSubnet.Total)
+		set(m.subnetAllocated, sLabels, cSubnet.Allocated)
+		set(m.subnetFree, sLabels, cSubnet.Total-cSubnet.Allocated)
+	}
+}
+
+type IPBlockMetrics struct {
+	size      prometheus.GaugeVec
+	allocated prometheus.GaugeVec
+	usage     prometheus.GaugeVec
+}
+
+func NewIPBlockMetrics(namespace string) *IPBlockMetrics {
+	labels := []string{"id", "name", "cidr"}
+	return &IPBlockMetrics{
+		size: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_block_size",
+				Help:      "Number of addresses in ip block",
+			}, labels),
+		allocated: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_block_allocated",
+				Help:      "Number of addresses allocated to subnets of ip block",
+			}, labels),
+		usage: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ip_block_usage",
+				Help:      "Ratio of addresses allocated to subnets of ip block, between 0 and 1",
+			}, labels),
+	}
+}
+
+func (m *IPBlockMetrics) Reset() {
+	m.size.Reset()
+	m.allocated.Reset()
+	m.usage.Reset()
+}
+
+func (m *IPBlockMetrics) Populate(info api.IPBlockInfo) {
+	labels := []string{
+		info.ID,
+		info.Name,
+		info.CIDR,
+	}
+
+	set(m.size, labels, info.Size)
+	set(m.allocated, labels, info.Allocated)
+	if info.Size != 0 {
+		set(m.usage, labels, info.Allocated/info.Size)
+	}
+}
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// ipsec_vpn_session_info{gateway_id, id, name, peer, ike_status, fail_reason} 1
+// ipsec_vpn_session_status{gateway_id, id, name, status} 1 == UP
+// ipsec_vpn_session_ike_status{gateway_id, id, name, status} 1 == UP
+// ipsec_vpn_session_tunnel{gateway_id, id, name}
+// ipsec_vpn_session_tunnel_negotiated{gateway_id, id, name}
+// ipsec_vpn_session_tunnel_failed{gateway_id, id, name}
+// ipsec_vpn_session_{in,out}_{byte,packet,dropped}_total{gateway_id, id, name}
+// ipsec_vpn_tunnel_status{gateway_id, session_id, local_subnet, peer_subnet, status} 1 == UP
+// ipsec_vpn_tunnel_{in,out}_{byte,packet,dropped}_total{gateway_id, session_id, local_subnet, peer_subnet}
+
+type IPSecVpnMetrics struct {
+	info             prometheus.GaugeVec
+	status           prometheus.GaugeVec
+	ikeStatus        prometheus.GaugeVec
+	tunnel           prometheus.GaugeVec
+	tunnelNegotiated prometheus.GaugeVec
+	tunnelFailed     prometheus.GaugeVec
+	traffic          *TrafficMetrics
+	tunnels          *IPSecVpnTunnelMetrics
+}
+
+type IPSecVpnTunnelMetrics struct {
+	status  prometheus.GaugeVec
+	traffic *TrafficMetrics
+}
+
+func NewIPSecVpnTunnelMetrics(namespace string) *IPSecVpnTunnelMetrics {
+	labels := []string{"gateway_id", "session_id", "local_subnet", "peer_subnet"}
+	return &IPSecVpnTunnelMetrics{
+		traffic: NewTrafficMetrics(namespace, "ipsec_vpn_tunnel", labels, true),
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_tunnel_status",
+				Help:      "Gives status of ipsec vpn tunnel, 1 is UP",
+			}, slice(labels, "status")),
+	}
+}
+
+func (m *IPSecVpnTunnelMetrics) Reset() {
+	m.status.Reset()
+	m.traffic.Reset()
+}
+
+func (m *IPSecVpnTunnelMetrics) Populate(labels []string, stats model.IpSecVpnTunnelTrafficStatistics) {
+	labels = slice(
+		labels,
+		zero(stats.LocalSubnet),
+		zero(stats.PeerSubnet),
+	)
+	setv(m.status, labels, stats.TunnelStatus, StatusUp)
+	setcp(m.traffic.inByte, labels, stats.BytesIn)
+	setcp(m.traffic.outByte, labels, stats.BytesOut)
+	setcp(m.traffic.inPacket, labels, stats.PacketsIn)
+	setcp(m.traffic.outPacket, labels, stats.PacketsOut)
+	setcp(m.traffic.inDropped, labels, stats.DroppedPacketsIn)
+	setcp(m.traffic.outDropped, labels, stats.DroppedPacketsOut)
+}
+
+func NewIPSecVpnMetrics(namespace string) *IPSecVpnMetrics {
+	labels := []string{"gateway_id", "id", "name"}
+	return &IPSecVpnMetrics{
+		traffic: NewTrafficMetrics(namespace, "ipsec_vpn_session", labels, true),
+		tunnels: NewIPSecVpnTunnelMetrics(namespace),
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_session_info",
+				Help:      "Give informations as label about ipsec vpn session, value is always 1",
+			}, slice(labels, "peer", "ike_status", "fail_reason")),
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_session_status",
+				Help:      "Gives runtime status of ipsec vpn session, 1 is UP",
+			}, slice(labels, "status")),
+		ikeStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_session_ike_status",
+				Help:      "Gives IKE security association state of ipsec vpn session, 1 is UP",
+			}, slice(labels, "status")),
+		tunnel: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_session_tunnel",
+				Help:      "Total number of tunnels in ipsec vpn session",
+			}, labels),
+		tunnelNegotiated: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_session_tunnel_negotiated",
+				Help:      "Number of negotiated tunnels in ipsec vpn session",
+			}, labels),
+		tunnelFailed: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "ipsec_vpn_session_tunnel_failed",
+				Help:      "Number of failed tunnels in ipsec vpn session",
+			}, labels),
+	}
+}
+
+func (m *IPSecVpnMetrics) Reset() {
+	m.info.Reset()
+	m.status.Reset()
+	m.ikeStatus.Reset()
+	m.tunnel.Reset()
+	m.tunnelNegotiated.Reset()
+	m.tunnelFailed.Reset()
+	m.traffic.Reset()
+	m.tunnels.Reset()
+}
+
+func (m *IPSecVpnMetrics) Populate(info api.IPSecVpnSessionInfo) {
+	labels := []string{
+		info.GatewayID,
+		zero(info.Config.Id),
+		zero(info.Config.DisplayName),
+	}
+
+	ike := model.IPSecVpnIkeSessionStatus{}
+	if info.Status.IkeStatus != nil {
+		ike = *info.Status.IkeStatus
+	}
+	infoLabels := slice(
+		labels,
+		zero(info.Config.PeerAddress),
+		zero(ike.IkeSessionState),
+		zero(ike.FailReason),
+	)
+	set(m.info, infoLabels, 1)
+	setv(m.status, labels, info.Status.RuntimeStatus, StatusUp)
+	setv(m.ikeStatus, labels, ike.IkeSessionState, StatusUp)
+	setp(m.tunnel, labels, info.Status.TotalTunnels)
+	setp(m.tunnelNegotiated, labels, info.Status.NegotiatedTunnels)
+	setp(m.tunnelFailed, labels, info.Status.FailedTunnels)
+
+	if c := info.Stats.AggregateTrafficCounters; c != nil {
+		setcp(m.traffic.inByte, labels, c.BytesIn)
+		setcp(m.traffic.outByte, labels, c.BytesOut)
+		setcp(m.traffic.inPacket, labels, c.PacketsIn)
+		setcp(m.traffic.outPacket, labels, c.PacketsOut)
+		setcp(m.traffic.inDropped, labels, c.DroppedPacketsIn)
+		setcp(m.traffic.outDropped, labels, c.DroppedPacketsOut)
+	}
+
+	tunnelLabels := []string{
+		info.GatewayID,
+		zero(info.Config.Id),
+	}
+	for _, cPolicy := range info.Stats.PolicyStatistics {
+		for _, cTunnel := range cPolicy.TunnelStatistics {
+			m.tunnels.Populate(tunnelLabels, cTunnel)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// l2vpn_session_status{gateway_id, id, name, status} 1 == UP
+// l2vpn_session_tunnel_status{gateway_id, id, name, tunnel_id, status} 1 == UP
+// l2vpn_segment_{in,out}_{byte,packet}_total{gateway_id, session_id, segment_id}
+// l2vpn_segment_bum_{in,out}_{byte,packet}_total{gateway_id, session_id, segment_id}
+// l2vpn_segment_{in,out}_error_total{gateway_id, session_id, segment_id}
+
+type L2VpnMetrics struct {
+	status       prometheus.GaugeVec
+	tunnelStatus prometheus.GaugeVec
+	segments     *L2VpnSegmentMetrics
+}
+
+type L2VpnSegmentMetrics struct {
+	traffic    *TrafficMetrics
+	bumTraffic *TrafficMetrics
+	inError    *CounterVec
+	outError   *CounterVec
+}
+
+func NewL2VpnSegmentMetrics(namespace string) *L2VpnSegmentMetrics {
+	labels := []string{"gateway_id", "session_id", "segment_id"}
+	return &L2VpnSegmentMetrics{
+		traffic:    NewTrafficMetrics(namespace, "l2vpn_segment", labels, false),
+		bumTraffic: NewTrafficMetrics(namespace, "l2vpn_segment_bum", labels, false),
+		inError: NewCounterVec(
+			namespace,
+			"l2vpn_segment_in_error",
+			"Number of packets received with error by l2vpn segment",
+			labels, false),
+		outError: NewCounterVec(
+			namespace,
+			"l2vpn_segment_out_error",
+			"Number of packets sent with error by l2vpn segment",
+			labels, false),
+	}
+}
+
+func (m *L2VpnSegmentMetrics) Reset() {
+	m.traffic.Reset()
+	m.bumTraffic.Reset()
+	m.inError.Reset()
+	m.outError.Reset()
+}
+
+func (m *L2VpnSegmentMetrics) Populate(labels []string, stats model.L2VPNTrafficStatisticsPerSegment) {
+	labels = slice(labels, api.PathToID(zero(stats.SegmentPath)))
+	setcp(m.traffic.inByte, labels, stats.BytesIn)
+	setcp(m.traffic.outByte, labels, stats.BytesOut)
+	setcp(m.traffic.inPacket, labels, stats.PacketsIn)
+	setcp(m.traffic.outPacket, labels, stats.PacketsOut)
+	setcp(m.bumTraffic.inByte, labels, stats.BumBytesIn)
+	setcp(m.bumTraffic.outByte, labels, stats.BumBytesOut)
+	setcp(m.bumTraffic.inPacket, labels, stats.BumPacketsIn)
+	setcp(m.bumTraffic.outPacket, labels, stats.BumPacketsOut)
+	setcp(m.inError, labels, stats.PacketsReceiveError)
+	setcp(m.outError, labels, stats.PacketsSentError)
+}
+
+func NewL2VpnMetrics(namespace string) *L2VpnMetrics {
+	labels := []string{"gateway_id", "id", "name"}
+	return &L2VpnMetrics{
+		segments: NewL2VpnSegmentMetrics(namespace),
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "l2vpn_session_status",
+				Help:      "Gives runtime status of l2vpn session, 1 is UP",
+			}, slice(labels, "status")),
+		tunnelStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "l2vpn_session_tunnel_status",
+				Help:      "Gives runtime status of transport tunnel of l2vpn session, 1 is UP",
+			}, slice(labels, "tunnel_id", "status")),
+	}
+}
+
+func (m *L2VpnMetrics) Reset() {
+	m.status.Reset()
+	m.tunnelStatus.Reset()
+	m.segments.Reset()
+}
+
+func (m *L2VpnMetrics) Populate(info api.L2VpnSessionInfo) {
+	labels := []string{
+		info.GatewayID,
+		zero(info.Config.Id),
+		zero(info.Config.DisplayName),
+	}
+
+	setv(m.status, labels, info.Status.RuntimeStatus, StatusUp)
+	for _, cTunnel := range info.Tunnels {
+		var status *string
+		if cTunnel.SessionStatus != nil {
+			status = cTunnel.SessionStatus.RuntimeStatus
+		}
+		tunnelLabels := slice(labels, api.PathToID(zero(cTunnel.TransportTunnelPath)))
+		setv(m.tunnelStatus, tunnelLabels, status, StatusUp)
+	}
+
+	segmentLabels := []string{
+		info.GatewayID,
+		zero(info.Config.Id),
+	}
+	for _, cSegment := range info.Stats.TrafficStatisticsPerSegment {
+		m.segments.Populate(segmentLabels, cSegment)
+	}
+}
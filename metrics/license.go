@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// license_info{license, edition, product, capacity_type, eval} 1
+// license_expiry{license, edition}
+// license_expired{license, edition}
+// license_quantity{license, edition, capacity_type}
+// license_usage{feature, capacity_type}
+
+type LicenseMetrics struct {
+	info     prometheus.GaugeVec
+	expiry   prometheus.GaugeVec
+	expired  prometheus.GaugeVec
+	quantity prometheus.GaugeVec
+	usage    prometheus.GaugeVec
+}
+
+func NewLicenseMetrics(namespace string) *LicenseMetrics {
+	labels := []string{"license", "edition"}
+	return &LicenseMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "license_info",
+				Help:      "Give informations as label about license, value is always 1",
+			}, slice(labels, "product", "capacity_type", "eval")),
+		expiry: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "license_expiry",
+				Help:      "License expiry date expressed in number of second since EPOCH",
+			}, labels),
+		expired: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "license_expired",
+				Help:      "Is license expired, 1 when expired",
+			}, labels),
+		quantity: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "license_quantity",
+				Help:      "Licensed quantity of license by capacity type",
+			}, slice(labels, "capacity_type")),
+		usage: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "license_usage",
+				Help:      "Used quantity of licensed feature by capacity type",
+			}, []string{"feature", "capacity_type"}),
+	}
+}
+
+func (m *LicenseMetrics) Reset() {
+	m.info.Reset()
+	m.expiry.Reset()
+	m.expired.Reset()
+	m.quantity.Reset()
+	m.usage.Reset()
+}
+
+func (m *LicenseMetrics) Populate(info api.LicenseInfo) {
+	for _, cLicense := range info.Licenses {
+		labels := []string{
+			licenseID(cLicense.LicenseKey),
+			cLicense.Description,
+		}
+		set(m.info, slice(labels, cLicense.ProductName, cLicense.CapacityType, strconv.FormatBool(cLicense.IsEval)), 1)
+		setb(m.expired, labels, &cLicense.IsExpired)
+		set(m.quantity, slice(labels, cLicense.CapacityType), cLicense.Quantity)
+		if cLicense.Expiry != 0 {
+			set(m.expiry, labels, cLicense.Expiry/1000)
+		}
+	}
+
+	for _, cFeature := range info.Usage {
+		for _, cCapacity := range cFeature.CapacityUsage {
+			set(m.usage, []string{cFeature.Feature, cCapacity.CapacityType}, cCapacity.UsageCount)
+		}
+	}
+}
+
+// licenseID - only keeps last group of license key to avoid leaking it
+func licenseID(key string) string {
+	if len(key) <= 5 {
+		return key
+	}
+	return key[len(key)-5:]
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/orange-cloudfoundry/nsxt_exporter/api"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
 // load_balancer_enable{"name", "id"} 0|1
@@ -22,18 +23,45 @@ import (
 // load_balancer_l7_session_current{"name", "id"}
 // load_balancer_l7_session_total{"name", "id"}
 // load_balancer_l7_session_max{"name", "id"}
+// load_balancer_usage_virtual_server{"name", "id"}
+// load_balancer_usage_virtual_server_max{"name", "id"}
+// load_balancer_usage_pool{"name", "id"}
+// load_balancer_usage_pool_max{"name", "id"}
+// load_balancer_usage_pool_member{"name", "id"}
+// load_balancer_usage_pool_member_max{"name", "id"}
+// load_balancer_usage_percent{"name", "id"} %
+// load_balancer_usage_severity{"name", "id", "severity"} 1==GREEN
+// load_balancer_last_update_timestamp_seconds{"name", "id"}
+// load_balancer_summary_usage{"kind"}
+// load_balancer_summary_usage_max{"kind"}
+// load_balancer_summary_usage_percent{"kind"} %
+// load_balancer_summary_usage_severity{"kind", "severity"} 1==GREEN
 
 type LBMetrics struct {
-	enable    prometheus.GaugeVec
-	status    prometheus.GaugeVec
-	info      prometheus.GaugeVec
-	cpu       prometheus.GaugeVec
-	memory    prometheus.GaugeVec
-	error     prometheus.GaugeVec
-	alarm     prometheus.GaugeVec
-	vsCount   prometheus.GaugeVec
-	sessionL4 *SessionMetrics
-	sessionL7 *SessionMetrics
+	enable     prometheus.GaugeVec
+	status     prometheus.GaugeVec
+	info       prometheus.GaugeVec
+	cpu        prometheus.GaugeVec
+	memory     prometheus.GaugeVec
+	error      prometheus.GaugeVec
+	alarm      prometheus.GaugeVec
+	vsCount    prometheus.GaugeVec
+	sessionL4  *SessionMetrics
+	sessionL7  *SessionMetrics
+	usage      *LBUsageMetrics
+	vsMax      prometheus.GaugeVec
+	poolMax    prometheus.GaugeVec
+	lastUpdate prometheus.GaugeVec
+	summary    LBUsageSummaryMetrics
+}
+
+// LBUsageSummaryMetrics - capacity usage of all load balancer services by kind of object
+// (virtual_server, pool, pool_member)
+type LBUsageSummaryMetrics struct {
+	current  prometheus.GaugeVec
+	max      prometheus.GaugeVec
+	percent  prometheus.GaugeVec
+	severity prometheus.GaugeVec
 }
 
 func NewLBMetrics(namespace string) *LBMetrics {
@@ -89,6 +117,51 @@ func NewLBMetrics(namespace string) *LBMetrics {
 			}, labels),
 		sessionL4: NewSessionMetrics(namespace, "load_balancer", "l4", labels),
 		sessionL7: NewSessionMetrics(namespace, "load_balancer", "l7", labels),
+		usage:     NewLBUsageMetrics(namespace, "load_balancer", labels),
+		vsMax: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "load_balancer_usage_virtual_server_max",
+				Help:      "Maximum number of virtual servers in load_balancer",
+			}, labels),
+		poolMax: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "load_balancer_usage_pool_max",
+				Help:      "Maximum number of pools in load_balancer",
+			}, labels),
+		lastUpdate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "load_balancer_last_update_timestamp_seconds",
+				Help:      "Last refresh of load balancer statistics by NSX expressed in number of second since EPOCH",
+			}, labels),
+		summary: LBUsageSummaryMetrics{
+			current: *promauto.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Name:      "load_balancer_summary_usage",
+					Help:      "Current number of objects of given kind in all load balancers",
+				}, []string{"kind"}),
+			max: *promauto.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Name:      "load_balancer_summary_usage_max",
+					Help:      "Maximum number of objects of given kind in all load balancers",
+				}, []string{"kind"}),
+			percent: *promauto.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Name:      "load_balancer_summary_usage_percent",
+					Help:      "Capacity usage percentage of objects of given kind in all load balancers",
+				}, []string{"kind"}),
+			severity: *promauto.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Name:      "load_balancer_summary_usage_severity",
+					Help:      "Capacity usage severity of objects of given kind in all load balancers, 1 is GREEN",
+				}, []string{"kind", "severity"}),
+		},
 	}
 }
 
@@ -103,6 +176,14 @@ func (l *LBMetrics) Reset() {
 	l.vsCount.Reset()
 	l.sessionL4.Reset()
 	l.sessionL7.Reset()
+	l.usage.Reset()
+	l.vsMax.Reset()
+	l.poolMax.Reset()
+	l.lastUpdate.Reset()
+	l.summary.current.Reset()
+	l.summary.max.Reset()
+	l.summary.percent.Reset()
+	l.summary.severity.Reset()
 }
 
 func (l *LBMetrics) Populate(name string, id string, info *api.LBInfo) {
@@ -129,9 +210,44 @@ func (l *LBMetrics) Populate(name string, id string, info *api.LBInfo) {
 	setp(l.sessionL4.rate, labels, info.Stats.Statistics.L4CurrentSessionRate)
 	setp(l.sessionL4.current, labels, info.Stats.Statistics.L4CurrentSessions)
 	setp(l.sessionL4.max, labels, info.Stats.Statistics.L4MaxSessions)
-	setp(l.sessionL4.total, labels, info.Stats.Statistics.L4TotalSessions)
+	setcp(l.sessionL4.total, labels, info.Stats.Statistics.L4TotalSessions)
 	setp(l.sessionL7.rate, labels, info.Stats.Statistics.L7CurrentSessionRate)
 	setp(l.sessionL7.current, labels, info.Stats.Statistics.L7CurrentSessions)
 	setp(l.sessionL7.max, labels, info.Stats.Statistics.L7MaxSessions)
-	setp(l.sessionL7.total, labels, info.Stats.Statistics.L7TotalSessions)
+	setcp(l.sessionL7.total, labels, info.Stats.Statistics.L7TotalSessions)
+	if info.Stats.LastUpdateTimestamp != nil {
+		set(l.lastUpdate, labels, *info.Stats.LastUpdateTimestamp/1000)
+	}
+
+	if info.Usage != nil {
+		setp(l.usage.vs, labels, info.Usage.CurrentVirtualServerCount)
+		setp(l.vsMax, labels, info.Usage.VirtualServerCapacity)
+		setp(l.usage.pool, labels, info.Usage.CurrentPoolCount)
+		setp(l.poolMax, labels, info.Usage.PoolCapacity)
+		setp(l.usage.poolMember, labels, info.Usage.CurrentPoolMemberCount)
+		setp(l.usage.poolMemberMax, labels, info.Usage.PoolMemberCapacity)
+		setp(l.usage.percent, labels, info.Usage.UsagePercentage)
+		setv(l.usage.severity, labels, info.Usage.Severity, StatusGreen)
+	}
+}
+
+// PopulateUsageSummary - sets capacity usage of all load balancer services
+func (l *LBMetrics) PopulateUsageSummary(summary model.LBServiceUsageSummary) {
+	vs := []string{"virtual_server"}
+	setp(l.summary.current, vs, summary.CurrentVirtualServerCount)
+	setp(l.summary.max, vs, summary.VirtualServerCapacity)
+	setp(l.summary.percent, vs, summary.VirtualServerUsagePercentage)
+	setv(l.summary.severity, vs, summary.VirtualServerSeverity, StatusGreen)
+
+	pool := []string{"pool"}
+	setp(l.summary.current, pool, summary.CurrentPoolCount)
+	setp(l.summary.max, pool, summary.PoolCapacity)
+	setp(l.summary.percent, pool, summary.PoolUsagePercentage)
+	setv(l.summary.severity, pool, summary.PoolSeverity, StatusGreen)
+
+	member := []string{"pool_member"}
+	setp(l.summary.current, member, summary.CurrentPoolMemberCount)
+	setp(l.summary.max, member, summary.PoolMemberCapacity)
+	setp(l.summary.percent, member, summary.PoolMemberUsagePercentage)
+	setv(l.summary.severity, member, summary.PoolMemberSeverity, StatusGreen)
 }
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// monitor_info{id, name, type, kind, port} 1
+// monitor_interval{id, name}
+// monitor_timeout{id, name}
+// monitor_fall_count{id, name}
+// monitor_rise_count{id, name}
+// monitor_max_fails{id, name}
+
+type MonitorMetrics struct {
+	info      prometheus.GaugeVec
+	interval  prometheus.GaugeVec
+	timeout   prometheus.GaugeVec
+	fallCount prometheus.GaugeVec
+	riseCount prometheus.GaugeVec
+	maxFails  prometheus.GaugeVec
+}
+
+func NewMonitorMetrics(namespace string) *MonitorMetrics {
+	labels := []string{"id", "name"}
+	return &MonitorMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "monitor_info",
+				Help:      "Give informations as label about health monitor profile, value is always 1",
+			}, slice(labels, "type", "kind", "port")),
+		interval: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "monitor_interval",
+				Help:      "Number of seconds between two health checks of active monitor",
+			}, labels),
+		timeout: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "monitor_timeout",
+				Help:      "Number of seconds before health check or failed member is considered as timed out",
+			}, labels),
+		fallCount: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "monitor_fall_count",
+				Help:      "Number of consecutive failed checks before active monitor marks member DOWN",
+			}, labels),
+		riseCount: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "monitor_rise_count",
+				Help:      "Number of consecutive successful checks before active monitor marks member UP",
+			}, labels),
+		maxFails: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "monitor_max_fails",
+				Help:      "Number of consecutive connection failures before passive monitor marks member unavailable",
+			}, labels),
+	}
+}
+
+func (m *MonitorMetrics) Reset() {
+	m.info.Reset()
+	m.interval.Reset()
+	m.timeout.Reset()
+	m.fallCount.Reset()
+	m.riseCount.Reset()
+	m.maxFails.Reset()
+}
+
+func (m *MonitorMetrics) Populate(info api.MonitorInfo) {
+	labels := []string{
+		info.ID,
+		info.Name,
+	}
+
+	port := ""
+	if info.MonitorPort != nil {
+		port = fmt.Sprintf("%d", *info.MonitorPort)
+	}
+	set(m.info, slice(labels, info.Type, info.Kind, port), 1)
+	setp(m.interval, labels, info.Interval)
+	setp(m.timeout, labels, info.Timeout)
+	setp(m.fallCount, labels, info.FallCount)
+	setp(m.riseCount, labels, info.RiseCount)
+	setp(m.maxFails, labels, info.MaxFails)
+}
@@ -10,17 +10,17 @@ import (
 
 type InterfaceMetrics struct {
 	info      prometheus.GaugeVec
-	rxByte    prometheus.GaugeVec
-	rxDropped prometheus.GaugeVec
-	rxError   prometheus.GaugeVec
-	rxFrame   prometheus.GaugeVec
-	rxPacket  prometheus.GaugeVec
-	txByte    prometheus.GaugeVec
-	txCarrier prometheus.GaugeVec
-	txColl    prometheus.GaugeVec
-	txDropped prometheus.GaugeVec
-	txError   prometheus.GaugeVec
-	txPacket  prometheus.GaugeVec
+	rxByte    *CounterVec
+	rxDropped *CounterVec
+	rxError   *CounterVec
+	rxFrame   *CounterVec
+	rxPacket  *CounterVec
+	txByte    *CounterVec
+	txCarrier *CounterVec
+	txColl    *CounterVec
+	txDropped *CounterVec
+	txError   *CounterVec
+	txPacket  *CounterVec
 }
 
 type NodeMetrics struct {
@@ -37,10 +37,12 @@ type NodeMetrics struct {
 	uptime       prometheus.GaugeVec
 	version      prometheus.GaugeVec
 	certificates prometheus.GaugeVec
+	lastUpdate   prometheus.GaugeVec
 	interfaces   *InterfaceMetrics
 }
 
-func NewInterfaceMetrics(namespace string) *InterfaceMetrics {
+func NewInterfaceMetrics(namespace string, legacy bool) *InterfaceMetrics {
+	labels := []string{"uuid", "ip", "name", "dev"}
 	return &InterfaceMetrics{
 		info: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -48,72 +50,61 @@ func NewInterfaceMetrics(namespace string) *InterfaceMetrics {
 				Name:      "cluster_node_interface",
 				Help:      "Information about cluster node interface, value is always 1",
 			}, []string{"uuid", "ip", "name", "dev", "admin", "link", "mtu"}),
-		rxByte: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_rx_byte",
-				Help:      "Number of bytes received",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		rxDropped: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_rx_dropped",
-				Help:      "Number of packets dropped",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		rxError: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_rx_error",
-				Help:      "Number of receive errors",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		rxFrame: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_rx_frame",
-				Help:      "Number of framing errors",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		rxPacket: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_rx_packet",
-				Help:      "Number of packets received",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		txByte: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_tx_byte",
-				Help:      "Number of bytes transmitted",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		txCarrier: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_tx_carrier",
-				Help:      "Number of carrier losses detected",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		txColl: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_tx_coll",
-				Help:      "Number of collisions detected",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		txDropped: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_tx_dropped",
-				Help:      "Number of packets dropped",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		txError: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_tx_error",
-				Help:      "Number of transmit errors",
-			}, []string{"uuid", "ip", "name", "dev"}),
-		txPacket: *promauto.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Name:      "cluster_node_interface_tx_packet",
-				Help:      "Number of packets transmitted",
-			}, []string{"uuid", "ip", "name", "dev"}),
+		rxByte: NewCounterVec(
+			namespace,
+			"cluster_node_interface_rx_byte",
+			"Number of bytes received",
+			labels, legacy),
+		rxDropped: NewCounterVec(
+			namespace,
+			"cluster_node_interface_rx_dropped",
+			"Number of packets dropped",
+			labels, legacy),
+		rxError: NewCounterVec(
+			namespace,
+			"cluster_node_interface_rx_error",
+			"Number of receive errors",
+			labels, legacy),
+		rxFrame: NewCounterVec(
+			namespace,
+			"cluster_node_interface_rx_frame",
+			"Number of framing errors",
+			labels, legacy),
+		rxPacket: NewCounterVec(
+			namespace,
+			"cluster_node_interface_rx_packet",
+			"Number of packets received",
+			labels, legacy),
+		txByte: NewCounterVec(
+			namespace,
+			"cluster_node_interface_tx_byte",
+			"Number of bytes transmitted",
+			labels, legacy),
+		txCarrier: NewCounterVec(
+			namespace,
+			"cluster_node_interface_tx_carrier",
+			"Number of carrier losses detected",
+			labels, legacy),
+		txColl: NewCounterVec(
+			namespace,
+			"cluster_node_interface_tx_coll",
+			"Number of collisions detected",
+			labels, legacy),
+		txDropped: NewCounterVec(
+			namespace,
+			"cluster_node_interface_tx_dropped",
+			"Number of packets dropped",
+			labels, legacy),
+		txError: NewCounterVec(
+			namespace,
+			"cluster_node_interface_tx_error",
+			"Number of transmit errors",
+			labels, legacy),
+		txPacket: NewCounterVec(
+			namespace,
+			"cluster_node_interface_tx_packet",
+			"Number of packets transmitted",
+			labels, legacy),
 	}
 }
 
@@ -132,10 +123,10 @@ func (m *InterfaceMetrics) Reset() {
 	m.txPacket.Reset()
 }
 
-func NewNodeMetrics(namespace string) *NodeMetrics {
+func NewNodeMetrics(namespace string, legacy bool) *NodeMetrics {
 	labels := []string{"uuid", "ip", "name"}
 	return &NodeMetrics{
-		interfaces: NewInterfaceMetrics(namespace),
+		interfaces: NewInterfaceMetrics(namespace, legacy),
 		status: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -202,6 +193,12 @@ func NewNodeMetrics(namespace string) *NodeMetrics {
 				Name:      "cluster_node_uptime",
 				Help:      "Uptime expressed in millisecond since start",
 			}, labels),
+		lastUpdate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "cluster_node_last_update_timestamp_seconds",
+				Help:      "Node system time reported with its status expressed in number of second since EPOCH",
+			}, labels),
 		version: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -231,6 +228,7 @@ func (m *NodeMetrics) Reset() {
 	m.uptime.Reset()
 	m.version.Reset()
 	m.certificates.Reset()
+	m.lastUpdate.Reset()
 	m.interfaces.Reset()
 }
 
@@ -258,6 +256,9 @@ func (m *NodeMetrics) Populate(info *api.NodeInfo) error {
 	}
 
 	m.uptime.WithLabelValues(labels...).Set(float64(info.Status.SystemStatus.Uptime))
+	if info.Status.SystemStatus.SystemTime != 0 {
+		m.lastUpdate.WithLabelValues(labels...).Set(float64(info.Status.SystemStatus.SystemTime / 1000))
+	}
 
 	versionLabels := slice(labels, info.Status.Version)
 	m.version.WithLabelValues(versionLabels...).Set(float64(1))
@@ -293,17 +294,17 @@ func (m *NodeMetrics) Populate(info *api.NodeInfo) error {
 		iFaceLabels := slice(labels, cIface.Config.InterfaceId)
 		infoLabels := slice(iFaceLabels, cIface.Config.AdminStatus, cIface.Config.LinkStatus, fmt.Sprintf("%d", cIface.Config.Mtu))
 		m.interfaces.info.WithLabelValues(infoLabels...).Set(1)
-		m.interfaces.rxByte.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.RxBytes))
-		m.interfaces.rxDropped.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.RxDropped))
-		m.interfaces.rxError.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.RxErrors))
-		m.interfaces.rxFrame.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.RxFrame))
-		m.interfaces.rxPacket.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.RxPackets))
-		m.interfaces.txByte.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.TxBytes))
-		m.interfaces.txCarrier.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.TxCarrier))
-		m.interfaces.txColl.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.TxColls))
-		m.interfaces.txDropped.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.TxDropped))
-		m.interfaces.txError.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.TxErrors))
-		m.interfaces.txPacket.WithLabelValues(iFaceLabels...).Set(float64(cIface.Stats.TxPackets))
+		setc(m.interfaces.rxByte, iFaceLabels, cIface.Stats.RxBytes)
+		setc(m.interfaces.rxDropped, iFaceLabels, cIface.Stats.RxDropped)
+		setc(m.interfaces.rxError, iFaceLabels, cIface.Stats.RxErrors)
+		setc(m.interfaces.rxFrame, iFaceLabels, cIface.Stats.RxFrame)
+		setc(m.interfaces.rxPacket, iFaceLabels, cIface.Stats.RxPackets)
+		setc(m.interfaces.txByte, iFaceLabels, cIface.Stats.TxBytes)
+		setc(m.interfaces.txCarrier, iFaceLabels, cIface.Stats.TxCarrier)
+		setc(m.interfaces.txColl, iFaceLabels, cIface.Stats.TxColls)
+		setc(m.interfaces.txDropped, iFaceLabels, cIface.Stats.TxDropped)
+		setc(m.interfaces.txError, iFaceLabels, cIface.Stats.TxErrors)
+		setc(m.interfaces.txPacket, iFaceLabels, cIface.Stats.TxPackets)
 	}
 
 	return nil
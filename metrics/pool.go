@@ -14,9 +14,14 @@ import (
 // pool_member{name, id}
 // pool_alarm{name, id, error_id, message}
 // pool_status{name, id} 1 == up
+// pool_monitor{name, id, monitor_id, kind} 1
+// pool_last_update_timestamp_seconds{name, id}
 
 // pool_member_failure{name, id, ip, port}
 // pool_member_status{name, id, ip, port} 1 == up
+// pool_member_info{name, id, ip, port, member_name, backup_member, admin_state, weight} 1
+// pool_member_last_check{name, id, ip, port}
+// pool_member_last_state_change{name, id, ip, port}
 
 type PoolMetrics struct {
 	NetworkMetrics
@@ -26,20 +31,26 @@ type PoolMetrics struct {
 	status      prometheus.GaugeVec
 	memberCount prometheus.GaugeVec
 	memberMin   prometheus.GaugeVec
+	monitor     prometheus.GaugeVec
+	lastUpdate  prometheus.GaugeVec
 	member      *MemberMetrics
+	monitors    *MonitorMetrics
 }
 
 type MemberMetrics struct {
 	NetworkMetrics
 
-	failure prometheus.GaugeVec
-	status  prometheus.GaugeVec
+	failure         prometheus.GaugeVec
+	status          prometheus.GaugeVec
+	info            prometheus.GaugeVec
+	lastCheck       prometheus.GaugeVec
+	lastStateChange prometheus.GaugeVec
 }
 
-func NewMemberMetrics(namespace string, labels []string) *MemberMetrics {
+func NewMemberMetrics(namespace string, labels []string, legacy bool) *MemberMetrics {
 	labels = slice(labels, "ip", "port")
 	return &MemberMetrics{
-		NetworkMetrics: NewNetworkMetrics(namespace, "pool_member", labels),
+		NetworkMetrics: NewNetworkMetrics(namespace, "pool_member", labels, legacy),
 		status: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -52,6 +63,24 @@ func NewMemberMetrics(namespace string, labels []string) *MemberMetrics {
 				Name:      "pool_member_failure",
 				Help:      "Gives failure cause as label if any, value is always 1",
 			}, slice(labels, "cause")),
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "pool_member_info",
+				Help:      "Give informations as label about pool member, value is always 1",
+			}, slice(labels, "member_name", "backup_member", "admin_state", "weight")),
+		lastCheck: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "pool_member_last_check",
+				Help:      "Last health check of pool member expressed in number of second since EPOCH",
+			}, labels),
+		lastStateChange: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "pool_member_last_state_change",
+				Help:      "Last status change of pool member expressed in number of second since EPOCH",
+			}, labels),
 	}
 }
 
@@ -59,9 +88,13 @@ func (m *MemberMetrics) Reset() {
 	m.NetworkMetrics.Reset()
 	m.failure.Reset()
 	m.status.Reset()
+	m.info.Reset()
+	m.lastCheck.Reset()
+	m.lastStateChange.Reset()
 }
 
 func (m *MemberMetrics) Populate(
+	config *model.LBPoolMember,
 	status *model.LBPoolMemberStatus,
 	stats *model.LBPoolMemberStatistics,
 	labels []string,
@@ -74,14 +107,37 @@ func (m *MemberMetrics) Populate(
 
 	setl(m.failure, labels, status.FailureCause)
 	setv(m.status, labels, status.Status, StatusUp)
+	if status.LastCheckTime != nil {
+		set(m.lastCheck, labels, *status.LastCheckTime/1000)
+	}
+	if status.LastStateChangeTime != nil {
+		set(m.lastStateChange, labels, *status.LastStateChangeTime/1000)
+	}
 	m.NetworkMetrics.Populate(labels, stats.Statistics)
+
+	if config != nil {
+		// nsx defaults member weight to 1 when unset
+		weight := int64(1)
+		if config.Weight != nil {
+			weight = *config.Weight
+		}
+		infoLabels := slice(
+			labels,
+			zero(config.DisplayName),
+			fmt.Sprintf("%t", zero(config.BackupMember)),
+			zero(config.AdminState),
+			fmt.Sprintf("%d", weight),
+		)
+		set(m.info, infoLabels, 1)
+	}
 }
 
-func NewPoolMetrics(namespace string) *PoolMetrics {
+func NewPoolMetrics(namespace string, legacy bool) *PoolMetrics {
 	labels := []string{"name", "id"}
 	return &PoolMetrics{
-		NetworkMetrics: NewNetworkMetrics(namespace, "pool", labels),
-		member:         NewMemberMetrics(namespace, labels),
+		NetworkMetrics: NewNetworkMetrics(namespace, "pool", labels, legacy),
+		member:         NewMemberMetrics(namespace, labels, legacy),
+		monitors:       NewMonitorMetrics(namespace),
 		status: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -112,6 +168,18 @@ func NewPoolMetrics(namespace string) *PoolMetrics {
 				Name:      "pool_member_min",
 				Help:      "Minimum number of member to consider pool active",
 			}, slice(labels)),
+		monitor: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "pool_monitor",
+				Help:      "Health monitor profile bound to pool, value is always 1",
+			}, slice(labels, "monitor_id", "kind")),
+		lastUpdate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "pool_last_update_timestamp_seconds",
+				Help:      "Last refresh of pool statistics by NSX expressed in number of second since EPOCH",
+			}, labels),
 	}
 }
 
@@ -122,6 +190,9 @@ func (p *PoolMetrics) Reset() {
 	p.status.Reset()
 	p.member.Reset()
 	p.memberMin.Reset()
+	p.monitor.Reset()
+	p.monitors.Reset()
+	p.lastUpdate.Reset()
 }
 
 func (p *PoolMetrics) Populate(info api.PoolInfo) {
@@ -136,6 +207,9 @@ func (p *PoolMetrics) Populate(info api.PoolInfo) {
 	)
 
 	p.NetworkMetrics.Populate(labels, info.Stats.Statistics)
+	if info.Stats.LastUpdateTimestamp != nil {
+		set(p.lastUpdate, labels, *info.Stats.LastUpdateTimestamp/1000)
+	}
 	set(p.info, infoLabels, 1)
 	setv(p.status, labels, info.Status.Status, StatusUp)
 
@@ -150,7 +224,12 @@ func (p *PoolMetrics) Populate(info api.PoolInfo) {
 	set(p.memberCount, labels, len(info.Status.Members))
 	setp(p.memberMin, labels, info.Config.MinActiveMembers)
 
+	for _, cMonitor := range info.Monitors {
+		set(p.monitor, slice(labels, cMonitor.ID, cMonitor.Kind), 1)
+		p.monitors.Populate(cMonitor)
+	}
+
 	for i := range info.Members {
-		p.member.Populate(&info.Members[i].Status, &info.Members[i].Stats, labels)
+		p.member.Populate(info.Members[i].Config, &info.Members[i].Status, &info.Members[i].Stats, labels)
 	}
 }
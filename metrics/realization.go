@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// realization_state{path, type, entity_type, state} 1 == REALIZED
+// realization_error{path, type, message}
+// realization_timestamp_seconds{path, type, entity_type}
+
+type RealizationMetrics struct {
+	state     prometheus.GaugeVec
+	errors    prometheus.GaugeVec
+	timestamp prometheus.GaugeVec
+}
+
+func NewRealizationMetrics(namespace string) *RealizationMetrics {
+	labels := []string{"path", "type"}
+	return &RealizationMetrics{
+		state: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "realization_state",
+				Help:      "Gives realization state of policy object, 1 is REALIZED",
+			}, slice(labels, "entity_type", "state")),
+		errors: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "realization_error",
+				Help:      "Number of realization errors of policy object by message",
+			}, slice(labels, "message")),
+		timestamp: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "realization_timestamp_seconds",
+				Help:      "Last realization attempt of policy object expressed in number of second since EPOCH",
+			}, slice(labels, "entity_type")),
+	}
+}
+
+func (m *RealizationMetrics) Reset() {
+	m.state.Reset()
+	m.errors.Reset()
+	m.timestamp.Reset()
+}
+
+func (m *RealizationMetrics) Populate(info api.RealizationInfo) {
+	labels := []string{
+		zero(info.Intent.Path),
+		zero(info.Intent.ResourceType),
+	}
+
+	messages := map[string]int{}
+	for _, cEntity := range info.Entities {
+		entityLabels := slice(labels, zero(cEntity.EntityType))
+		setv(m.state, entityLabels, cEntity.State, model.GenericPolicyRealizedResource_STATE_REALIZED)
+		if cEntity.PublishTime != nil {
+			set(m.timestamp, entityLabels, *cEntity.PublishTime/1000)
+		}
+		for _, cAlarm := range cEntity.Alarms {
+			messages[zero(cAlarm.Message)]++
+		}
+		for _, cError := range []*string{cEntity.PublishStatusError, cEntity.RuntimeError} {
+			if zero(cError) != "" {
+				messages[*cError]++
+			}
+		}
+	}
+	for cMessage, cCount := range messages {
+		set(m.errors, slice(labels, cMessage), cCount)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 )
 
 type Recorder struct {
@@ -15,23 +16,67 @@ type Recorder struct {
 	scrapeDurationSeconds prometheus.Gauge
 	clusterControlStatus  prometheus.GaugeVec
 	clusterMgmtStatus     prometheus.GaugeVec
+	cluster               *ClusterMetrics
 	node                  *NodeMetrics
 	lb                    *LBMetrics
 	vs                    *VSMetrics
 	pool                  *PoolMetrics
 	tier0                 *Tier0Metrics
 	tier1                 *Tier1Metrics
+	firewall              *FirewallMetrics
+	ipsecVpn              *IPSecVpnMetrics
+	l2vpn                 *L2VpnMetrics
+	dhcp                  *DHCPMetrics
+	dnsForwarder          *DNSForwarderMetrics
+	segment               *SegmentMetrics
+	edgeNode              *EdgeNodeMetrics
+	hostNode              *HostNodeMetrics
+	transportZone         *TransportZoneMetrics
+	edgeCluster           *EdgeClusterMetrics
+	alarm                 *AlarmMetrics
+	realization           *RealizationMetrics
+	ipPool                *IPPoolMetrics
+	ipBlock               *IPBlockMetrics
+	license               *LicenseMetrics
+	backup                *BackupMetrics
+	certificate           *CertificateMetrics
+	computeManager        *ComputeManagerMetrics
+	upgrade               *UpgradeMetrics
 }
 
-func NewRecorder(manager *api.NSXApi, namespace string) *Recorder {
+// NewRecorder - creates all metrics of the exporter
+//
+// When legacyGauges is true, cumulative counters that used to be exported as gauges
+// are also published under their former names, without the _total suffix.
+func NewRecorder(manager *api.NSXApi, namespace string, legacyGauges bool) *Recorder {
 	return &Recorder{
-		manager: manager,
-		node:    NewNodeMetrics(namespace),
-		lb:      NewLBMetrics(namespace),
-		vs:      NewVSMetrics(namespace),
-		pool:    NewPoolMetrics(namespace),
-		tier0:   NewTier0Metrics(namespace),
-		tier1:   NewTier1Metrics(namespace),
+		manager:        manager,
+		cluster:        NewClusterMetrics(namespace),
+		node:           NewNodeMetrics(namespace, legacyGauges),
+		lb:             NewLBMetrics(namespace),
+		vs:             NewVSMetrics(namespace, legacyGauges),
+		pool:           NewPoolMetrics(namespace, legacyGauges),
+		tier0:          NewTier0Metrics(namespace),
+		tier1:          NewTier1Metrics(namespace),
+		firewall:       NewFirewallMetrics(namespace),
+		ipsecVpn:       NewIPSecVpnMetrics(namespace),
+		l2vpn:          NewL2VpnMetrics(namespace),
+		dhcp:           NewDHCPMetrics(namespace),
+		dnsForwarder:   NewDNSForwarderMetrics(namespace),
+		segment:        NewSegmentMetrics(namespace),
+		edgeNode:       NewEdgeNodeMetrics(namespace),
+		hostNode:       NewHostNodeMetrics(namespace),
+		transportZone:  NewTransportZoneMetrics(namespace),
+		edgeCluster:    NewEdgeClusterMetrics(namespace),
+		alarm:          NewAlarmMetrics(namespace),
+		realization:    NewRealizationMetrics(namespace),
+		ipPool:         NewIPPoolMetrics(namespace),
+		ipBlock:        NewIPBlockMetrics(namespace),
+		license:        NewLicenseMetrics(namespace),
+		backup:         NewBackupMetrics(namespace),
+		certificate:    NewCertificateMetrics(namespace),
+		computeManager: NewComputeManagerMetrics(namespace),
+		upgrade:        NewUpgradeMetrics(namespace),
 		scrapeError: promauto.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -60,10 +105,30 @@ func NewRecorder(manager *api.NSXApi, namespace string) *Recorder {
 }
 
 func (r *Recorder) Reset() {
+	r.cluster.Reset()
 	r.node.Reset()
 	r.lb.Reset()
 	r.vs.Reset()
 	r.pool.Reset()
+	r.firewall.Reset()
+	r.ipsecVpn.Reset()
+	r.l2vpn.Reset()
+	r.dhcp.Reset()
+	r.dnsForwarder.Reset()
+	r.segment.Reset()
+	r.edgeNode.Reset()
+	r.hostNode.Reset()
+	r.transportZone.Reset()
+	r.edgeCluster.Reset()
+	r.alarm.Reset()
+	r.realization.Reset()
+	r.ipPool.Reset()
+	r.ipBlock.Reset()
+	r.license.Reset()
+	r.backup.Reset()
+	r.certificate.Reset()
+	r.computeManager.Reset()
+	r.upgrade.Reset()
 	r.scrapeError.Set(0)
 }
 
@@ -102,6 +167,20 @@ func (r *Recorder) RecordMetrics() error {
 		}
 	}
 
+	// cluster groups
+	if cluster.DetailedClusterStatus != nil {
+		r.cluster.Populate(*cluster.DetailedClusterStatus)
+	}
+
+	// cluster node services
+	for _, cNode := range cluster.MgmtClusterStatus.OnlineNodes {
+		services, err := r.manager.GetClusterNodeServices(cNode.Uuid)
+		if err != nil {
+			r.scrapeError.Set(1)
+		}
+		r.cluster.PopulateServices(cNode.Uuid, services)
+	}
+
 	// lb
 	lbs, err := r.manager.ListLoadBalancers()
 	if err != nil {
@@ -116,6 +195,9 @@ func (r *Recorder) RecordMetrics() error {
 			r.scrapeError.Set(1)
 			return err
 		}
+		if info.Incomplete {
+			r.scrapeError.Set(1)
+		}
 		r.lb.Populate(*cLb.DisplayName, *cLb.Id, info)
 		// virtual server
 		for _, cVS := range info.VirtualServers {
@@ -126,12 +208,20 @@ func (r *Recorder) RecordMetrics() error {
 			r.pool.Populate(cPool)
 		}
 	}
+	lbSummary, err := r.manager.GetLBServiceUsageSummary()
+	if err != nil {
+		r.scrapeError.Set(1)
+	} else {
+		r.lb.PopulateUsageSummary(*lbSummary)
+	}
 
 	t1GWs, err := r.manager.ListT1()
 	if err != nil {
 		r.scrapeError.Set(1)
 		return err
 	}
+	// statuses are kept for edge cluster service router placement
+	t1Statuses := []*model.LogicalRouterStatus{}
 	for _, cT1 := range t1GWs {
 		state, err := r.manager.GetT1Status(*cT1.Id)
 		if err != nil {
@@ -139,6 +229,7 @@ func (r *Recorder) RecordMetrics() error {
 			return err
 		}
 		r.tier1.Populate(cT1, state.Tier1State, state.Tier1Status)
+		t1Statuses = append(t1Statuses, state.Tier1Status)
 	}
 
 	t0GWs, err := r.manager.ListT0()
@@ -146,6 +237,8 @@ func (r *Recorder) RecordMetrics() error {
 		r.scrapeError.Set(1)
 		return err
 	}
+	// statuses are kept for edge cluster service router placement
+	t0Statuses := []*model.LogicalRouterStatus{}
 	for _, cT0 := range t0GWs {
 		state, err := r.manager.GetT0Status(*cT0.Id)
 		if err != nil {
@@ -153,6 +246,293 @@ func (r *Recorder) RecordMetrics() error {
 			return err
 		}
 		r.tier0.Populate(cT0, state.Tier0State, state.Tier0Status)
+		t0Statuses = append(t0Statuses, state.Tier0Status)
+	}
+
+	// ipsec vpn
+	for _, cT0 := range t0GWs {
+		sessions, err := r.manager.GetT0IPSecVpnSessionInfos(*cT0.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		for _, cSession := range sessions {
+			r.ipsecVpn.Populate(cSession)
+		}
+	}
+	for _, cT1 := range t1GWs {
+		sessions, err := r.manager.GetT1IPSecVpnSessionInfos(*cT1.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		for _, cSession := range sessions {
+			r.ipsecVpn.Populate(cSession)
+		}
+	}
+
+	// l2vpn
+	for _, cT0 := range t0GWs {
+		sessions, err := r.manager.GetT0L2VpnSessionInfos(*cT0.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		for _, cSession := range sessions {
+			r.l2vpn.Populate(cSession)
+		}
+	}
+	for _, cT1 := range t1GWs {
+		sessions, err := r.manager.GetT1L2VpnSessionInfos(*cT1.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		for _, cSession := range sessions {
+			r.l2vpn.Populate(cSession)
+		}
+	}
+
+	zones, err := r.manager.ListTransportZones()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	segments, err := r.manager.ListSegments()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cT1 := range t1GWs {
+		t1Segments, err := r.manager.ListT1Segments(*cT1.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		segments = append(segments, t1Segments...)
+	}
+
+	// dhcp
+	dhcpServers, err := r.manager.GetDHCPServerInfos(t0GWs, t1GWs, segments)
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cServer := range dhcpServers {
+		r.dhcp.Populate(cServer)
+	}
+	dhcpRelays, err := r.manager.GetDHCPRelayInfos(t0GWs, t1GWs, segments)
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cRelay := range dhcpRelays {
+		r.dhcp.PopulateRelay(cRelay)
+	}
+
+	// dns forwarder
+	for _, cT0 := range t0GWs {
+		forwarder, err := r.manager.GetT0DNSForwarderInfo(*cT0.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		if forwarder != nil {
+			r.dnsForwarder.Populate(*forwarder)
+		}
+	}
+	for _, cT1 := range t1GWs {
+		forwarder, err := r.manager.GetT1DNSForwarderInfo(*cT1.Id)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		if forwarder != nil {
+			r.dnsForwarder.Populate(*forwarder)
+		}
+	}
+
+	// segment
+	for _, cSegment := range segments {
+		info, err := r.manager.GetSegmentInfo(cSegment, zones)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.segment.Populate(*info)
+	}
+
+	// edge node
+	edgeClusters, err := r.manager.ListEdgeClusters()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	edges, err := r.manager.ListEdgeNodes()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	lbNodeUsages, err := r.manager.ListLBNodeUsages()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cEdge := range edges {
+		info, err := r.manager.GetEdgeNodeInfo(cEdge, edgeClusters)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.edgeNode.Populate(*info)
+		r.edgeNode.PopulateLBUsage(*info, lbNodeUsages)
+	}
+
+	nodeStatuses, err := r.manager.ListTransportNodeStatus()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+
+	// host node
+	hosts, err := r.manager.ListHostNodes()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cHost := range hosts {
+		info, err := r.manager.GetHostNodeInfo(cHost, nodeStatuses)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.hostNode.Populate(*info)
+	}
+
+	// transport zone
+	for _, cZone := range zones {
+		info, err := r.manager.GetTransportZoneInfo(cZone)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.transportZone.Populate(*info)
+	}
+
+	// edge cluster
+	edgeDeployments, err := r.manager.ListEdgeDeploymentTypes()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cCluster := range edgeClusters {
+		info, err := r.manager.GetEdgeClusterInfo(cCluster, nodeStatuses, edgeDeployments)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.edgeCluster.Populate(*info, t0Statuses, t1Statuses)
+	}
+
+	// alarm
+	alarms, err := r.manager.ListOpenAlarms()
+	if err != nil {
+		r.scrapeError.Set(1)
+	} else {
+		r.alarm.Populate(alarms, r.manager.AlarmSeverities())
+	}
+
+	// realization
+	intents, err := r.manager.ListRealizationIntents()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cIntent := range intents {
+		info, err := r.manager.GetRealizationInfo(cIntent)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.realization.Populate(*info)
+	}
+
+	// ip pool
+	ipPools, err := r.manager.GetIPPoolInfos()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cPool := range ipPools {
+		r.ipPool.Populate(cPool)
+	}
+
+	// ip block
+	ipBlocks, err := r.manager.GetIPBlockInfos()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cBlock := range ipBlocks {
+		r.ipBlock.Populate(cBlock)
+	}
+
+	// license
+	license, err := r.manager.GetLicenseInfo()
+	if err != nil {
+		r.scrapeError.Set(1)
+	} else {
+		r.license.Populate(*license)
+	}
+
+	// backup
+	backup, err := r.manager.GetBackupInfo()
+	if err != nil {
+		r.scrapeError.Set(1)
+	} else {
+		r.backup.Populate(*backup)
+	}
+
+	// trust store
+	certs, err := r.manager.ListCertificates()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	r.certificate.PopulateCertificates(certs)
+	bundles, err := r.manager.ListCaBundles()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	r.certificate.PopulateCaBundles(bundles)
+	crls, err := r.manager.ListCrls()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	r.certificate.PopulateCrls(crls)
+
+	// compute manager
+	computeManagers, err := r.manager.ListComputeManagers()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cManager := range computeManagers {
+		info, err := r.manager.GetComputeManagerInfo(cManager)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		r.computeManager.Populate(*info)
+	}
+
+	// upgrade
+	upgrade, err := r.manager.GetUpgradeInfo()
+	if err != nil {
+		r.scrapeError.Set(1)
+	} else {
+		r.upgrade.Populate(*upgrade)
+	}
+
+	// distributed firewall
+	domains, err := r.manager.ListDomains()
+	if err != nil {
+		r.scrapeError.Set(1)
+	}
+	for _, cDomain := range domains {
+		policies, err := r.manager.GetSecurityPolicyInfos(cDomain)
+		if err != nil {
+			r.scrapeError.Set(1)
+			continue
+		}
+		for _, cPolicy := range policies {
+			r.firewall.Populate(cPolicy)
+		}
 	}
 
 	log.Infof("fetching data from nsxt finished after %.0fs", time.Since(start).Seconds())
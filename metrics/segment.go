@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+// segment_info{id, name, gateway_id, connectivity_id, transport_zone_id, type, subnets} 1
+// segment_status{id, name, gateway_id, status} 1 == success
+// segment_port{id, name, gateway_id}
+// segment_port_admin_state{id, name, gateway_id, state}
+// segment_port_status{id, name, gateway_id, status}
+// segment_{in,out}_{byte,packet,dropped}_total{id, name, gateway_id}
+
+type SegmentMetrics struct {
+	info           prometheus.GaugeVec
+	status         prometheus.GaugeVec
+	port           prometheus.GaugeVec
+	portAdminState prometheus.GaugeVec
+	portStatus     prometheus.GaugeVec
+	traffic        *TrafficMetrics
+}
+
+func NewSegmentMetrics(namespace string) *SegmentMetrics {
+	labels := []string{"id", "name", "gateway_id"}
+	return &SegmentMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "segment_info",
+				Help:      "Give informations as label about segment, value is always 1",
+			}, slice(labels, "connectivity_id", "transport_zone_id", "type", "subnets")),
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "segment_status",
+				Help:      "Give realization state of segment, 1 is success",
+			}, slice(labels, "status")),
+		port: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "segment_port",
+				Help:      "Number of ports of segment",
+			}, labels),
+		portAdminState: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "segment_port_admin_state",
+				Help:      "Number of ports of segment by admin state",
+			}, slice(labels, "state")),
+		portStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "segment_port_status",
+				Help:      "Number of ports of segment by operational status",
+			}, slice(labels, "status")),
+		traffic: NewTrafficMetrics(namespace, "segment", labels, true),
+	}
+}
+
+func (m *SegmentMetrics) Reset() {
+	m.info.Reset()
+	m.status.Reset()
+	m.port.Reset()
+	m.portAdminState.Reset()
+	m.portStatus.Reset()
+	m.traffic.Reset()
+}
+
+// Populate - gateway_id is the parent tier1 gateway of segment, empty for global segments
+func (m *SegmentMetrics) Populate(info api.SegmentInfo) {
+	labels := []string{
+		zero(info.Config.Id),
+		zero(info.Config.DisplayName),
+		info.TierID,
+	}
+
+	kind := strings.ToLower(info.TransportType)
+	if kind == "" {
+		kind = "unknown"
+	}
+	subnets := []string{}
+	for _, cSubnet := range info.Config.Subnets {
+		subnets = append(subnets, zero(cSubnet.Network))
+	}
+	infoLabels := slice(
+		labels,
+		api.PathToID(zero(info.Config.ConnectivityPath)),
+		api.PathToID(zero(info.Config.TransportZonePath)),
+		kind,
+		strings.Join(subnets, ","),
+	)
+	set(m.info, infoLabels, 1)
+	setv(m.status, labels, info.State.State, StatusSuccess)
+
+	// admin state defaults to UP when not given
+	states := map[string]int{
+		model.SegmentPort_ADMIN_STATE_UP:   0,
+		model.SegmentPort_ADMIN_STATE_DOWN: 0,
+	}
+	for _, cPort := range info.Ports {
+		state := model.SegmentPort_ADMIN_STATE_UP
+		if cPort.AdminState != nil {
+			state = *cPort.AdminState
+		}
+		states[state]++
+	}
+	set(m.port, labels, len(info.Ports))
+	for cState, cCount := range states {
+		set(m.portAdminState, slice(labels, cState), cCount)
+	}
+	if info.PortStatus != nil {
+		set(m.portStatus, slice(labels, StatusUp), info.PortStatus.UpPorts)
+		set(m.portStatus, slice(labels, "DOWN"), info.PortStatus.TotalPorts-info.PortStatus.UpPorts)
+	}
+
+	if c := info.Stats.RxBytes; c != nil {
+		setcp(m.traffic.inByte, labels, c.Total)
+	}
+	if c := info.Stats.TxBytes; c != nil {
+		setcp(m.traffic.outByte, labels, c.Total)
+	}
+	if c := info.Stats.RxPackets; c != nil {
+		setcp(m.traffic.inPacket, labels, c.Total)
+		setcp(m.traffic.inDropped, labels, c.Dropped)
+	}
+	if c := info.Stats.TxPackets; c != nil {
+		setcp(m.traffic.outPacket, labels, c.Total)
+		setcp(m.traffic.outDropped, labels, c.Dropped)
+	}
+}
@@ -9,10 +9,15 @@ import (
 )
 
 const (
-	StatusStable    = "STABLE"
-	StatusConnected = "CONNECTED"
-	StatusUp        = "UP"
-	StatusInSync    = "in_sync"
+	StatusStable         = "STABLE"
+	StatusConnected      = "CONNECTED"
+	StatusUp             = "UP"
+	StatusInSync         = "in_sync"
+	StatusSuccess        = "success"
+	StatusRegistered     = "REGISTERED"
+	StatusUpgradeSuccess = "SUCCESS"
+	StatusRunning        = "running"
+	StatusGreen          = "GREEN"
 )
 
 type floatable interface {
@@ -37,6 +42,16 @@ func setp[T floatable](metric prometheus.GaugeVec, labels []string, value *T) {
 	}
 }
 
+func setc[T floatable](metric *CounterVec, labels []string, value T) {
+	metric.Set(labels, float64(value))
+}
+
+func setcp[T floatable](metric *CounterVec, labels []string, value *T) {
+	if value != nil {
+		metric.Set(labels, float64(*value))
+	}
+}
+
 func setb(metric prometheus.GaugeVec, labels []string, value *bool) {
 	v := 0
 	if value != nil && *value {
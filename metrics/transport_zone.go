@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// transport_zone_info{id, name, type, host_switch} 1
+// transport_zone_node{id, name}
+// transport_zone_node_status{id, name, status}
+// transport_zone_switch{id, name}
+// transport_zone_port{id, name}
+
+type TransportZoneMetrics struct {
+	info       prometheus.GaugeVec
+	node       prometheus.GaugeVec
+	nodeStatus prometheus.GaugeVec
+	switches   prometheus.GaugeVec
+	port       prometheus.GaugeVec
+}
+
+func NewTransportZoneMetrics(namespace string) *TransportZoneMetrics {
+	labels := []string{"id", "name"}
+	return &TransportZoneMetrics{
+		info: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transport_zone_info",
+				Help:      "Give informations as label about transport zone, value is always 1",
+			}, slice(labels, "type", "host_switch")),
+		node: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transport_zone_node",
+				Help:      "Number of transport nodes in transport zone",
+			}, labels),
+		nodeStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transport_zone_node_status",
+				Help:      "Number of transport nodes in transport zone by status",
+			}, slice(labels, "status")),
+		switches: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transport_zone_switch",
+				Help:      "Number of logical switches (segments) in transport zone",
+			}, labels),
+		port: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transport_zone_port",
+				Help:      "Number of logical ports in transport zone",
+			}, labels),
+	}
+}
+
+func (m *TransportZoneMetrics) Reset() {
+	m.info.Reset()
+	m.node.Reset()
+	m.nodeStatus.Reset()
+	m.switches.Reset()
+	m.port.Reset()
+}
+
+func (m *TransportZoneMetrics) Populate(info api.TransportZoneInfo) {
+	labels := []string{
+		info.Zone.Id,
+		info.Zone.DisplayName,
+	}
+
+	set(m.info, slice(labels, info.Zone.TransportType, info.Zone.HostSwitchName), 1)
+	set(m.node, labels, info.Status.NumTransportNodes)
+	set(m.switches, labels, info.Status.NumLogicalSwitches)
+	set(m.port, labels, info.Status.NumLogicalPorts)
+	set(m.nodeStatus, slice(labels, "UP"), info.Heatmap.UpCount)
+	set(m.nodeStatus, slice(labels, "DEGRADED"), info.Heatmap.DegradedCount)
+	set(m.nodeStatus, slice(labels, "DOWN"), info.Heatmap.DownCount)
+	set(m.nodeStatus, slice(labels, "UNKNOWN"), info.Heatmap.UnknownCount)
+}
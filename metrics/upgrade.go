@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"github.com/orange-cloudfoundry/nsxt_exporter/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// upgrade_status{status} 1 == SUCCESS
+// upgrade_component_status{component, status} 1 == SUCCESS
+// upgrade_component_percent{component}
+// upgrade_component_failed{component}
+// upgrade_group_percent{component, id, name}
+// upgrade_group_failed{component, id, name}
+// upgrade_unit_version{component, version}
+
+type UpgradeMetrics struct {
+	status           prometheus.GaugeVec
+	componentStatus  prometheus.GaugeVec
+	componentPercent prometheus.GaugeVec
+	componentFailed  prometheus.GaugeVec
+	groupPercent     prometheus.GaugeVec
+	groupFailed      prometheus.GaugeVec
+	unitVersion      prometheus.GaugeVec
+}
+
+func NewUpgradeMetrics(namespace string) *UpgradeMetrics {
+	labels := []string{"component"}
+	groupLabels := slice(labels, "id", "name")
+	return &UpgradeMetrics{
+		status: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_status",
+				Help:      "Gives overall upgrade status, 1 is SUCCESS",
+			}, []string{"status"}),
+		componentStatus: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_component_status",
+				Help:      "Gives upgrade status of component, 1 is SUCCESS",
+			}, slice(labels, "status")),
+		componentPercent: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_component_percent",
+				Help:      "Upgrade completion percentage of component",
+			}, labels),
+		componentFailed: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_component_failed",
+				Help:      "Number of upgrade units that failed to upgrade in component",
+			}, labels),
+		groupPercent: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_group_percent",
+				Help:      "Upgrade completion percentage of upgrade unit group",
+			}, groupLabels),
+		groupFailed: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_group_failed",
+				Help:      "Number of upgrade units that failed to upgrade in upgrade unit group",
+			}, groupLabels),
+		unitVersion: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "upgrade_unit_version",
+				Help:      "Number of upgrade units of component running given version",
+			}, slice(labels, "version")),
+	}
+}
+
+func (m *UpgradeMetrics) Reset() {
+	m.status.Reset()
+	m.componentStatus.Reset()
+	m.componentPercent.Reset()
+	m.componentFailed.Reset()
+	m.groupPercent.Reset()
+	m.groupFailed.Reset()
+	m.unitVersion.Reset()
+}
+
+func (m *UpgradeMetrics) Populate(info api.UpgradeInfo) {
+	status := info.Status.OverallUpgradeStatus
+	set(m.status, []string{status}, statusToValue(status, StatusUpgradeSuccess))
+
+	for _, cComponent := range info.Status.ComponentStatus {
+		labels := []string{cComponent.ComponentType}
+		set(m.componentStatus, slice(labels, cComponent.Status), statusToValue(cComponent.Status, StatusUpgradeSuccess))
+		set(m.componentPercent, labels, cComponent.PercentComplete)
+
+		failed := int32(0)
+		for _, cGroup := range info.Groups[cComponent.ComponentType] {
+			groupLabels := slice(labels, cGroup.GroupId, cGroup.GroupName)
+			set(m.groupPercent, groupLabels, cGroup.PercentComplete)
+			set(m.groupFailed, groupLabels, cGroup.FailedCount)
+			failed += cGroup.FailedCount
+		}
+		set(m.componentFailed, labels, failed)
+	}
+
+	versions := map[string]map[string]int{}
+	for _, cUnit := range info.Units {
+		if _, ok := versions[cUnit.Type_]; !ok {
+			versions[cUnit.Type_] = map[string]int{}
+		}
+		versions[cUnit.Type_][cUnit.CurrentVersion]++
+	}
+	for cComponent, cVersions := range versions {
+		for cVersion, cCount := range cVersions {
+			set(m.unitVersion, []string{cComponent, cVersion}, cCount)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"strings"
+
 	"github.com/orange-cloudfoundry/nsxt_exporter/api"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -14,34 +16,38 @@ import (
 // virtual_server_alarm{name, id}
 // virtual_server_session{name, id}
 // virtual_server_session_rate{name, id}
-// virtual_server_http_request{name, id}
+// virtual_server_http_request_total{name, id}
 // virtual_server_http_request_rate{name, id}
-// virtual_server_in_packet{name, id}
+// virtual_server_in_packet_total{name, id}
 // virtual_server_in_packet_rate{name, id}
-// virtual_server_out_packet{name, id}
+// virtual_server_out_packet_total{name, id}
 // virtual_server_out_packet_rate{name, id}
-// virtual_server_in_byte{name, id}
+// virtual_server_in_byte_total{name, id}
 // virtual_server_in_byte_rate{name, id}
-// virtual_server_out_bytes{name, id}
+// virtual_server_out_bytes_total{name, id}
 // virtual_server_out_bytes_rate{name, id}
 // virtual_server_session_max{name, id}
 // virtual_server_session_total{name, id}
 // virtual_server_source_ip{name, id}
+// virtual_server_certificate_expiry{name, id, ip, port, certificate_id, type, sni}
+// virtual_server_last_update_timestamp_seconds{name, id}
 
 type VSMetrics struct {
 	NetworkMetrics
 
-	enable prometheus.GaugeVec
-	status prometheus.GaugeVec
-	info   prometheus.GaugeVec
-	alarm  prometheus.GaugeVec
-	ip     prometheus.GaugeVec
+	enable      prometheus.GaugeVec
+	status      prometheus.GaugeVec
+	info        prometheus.GaugeVec
+	alarm       prometheus.GaugeVec
+	ip          prometheus.GaugeVec
+	certificate prometheus.GaugeVec
+	lastUpdate  prometheus.GaugeVec
 }
 
-func NewVSMetrics(namespace string) *VSMetrics {
+func NewVSMetrics(namespace string, legacy bool) *VSMetrics {
 	labels := []string{"name", "id"}
 	return &VSMetrics{
-		NetworkMetrics: NewNetworkMetrics(namespace, "virtual_server", labels),
+		NetworkMetrics: NewNetworkMetrics(namespace, "virtual_server", labels, legacy),
 		enable: *promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -72,6 +78,18 @@ func NewVSMetrics(namespace string) *VSMetrics {
 				Name:      "virtual_server_source_ip",
 				Help:      "Number of source IP persistence entries in virtual server",
 			}, labels),
+		certificate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "virtual_server_certificate_expiry",
+				Help:      "Virtual server SSL certificate validity end date expressed in number of second since EPOCH",
+			}, slice(labels, "ip", "port", "certificate_id", "type", "sni")),
+		lastUpdate: *promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "virtual_server_last_update_timestamp_seconds",
+				Help:      "Last refresh of virtual server statistics by NSX expressed in number of second since EPOCH",
+			}, labels),
 	}
 }
 
@@ -83,6 +101,8 @@ func (v *VSMetrics) Reset() {
 	v.alarm.Reset()
 	v.ip.Reset()
 	v.http.Reset()
+	v.certificate.Reset()
+	v.lastUpdate.Reset()
 }
 
 func (v *VSMetrics) Populate(info api.VSInfo) {
@@ -112,4 +132,27 @@ func (v *VSMetrics) Populate(info api.VSInfo) {
 	set(v.info, infoLabels, 1)
 	setp(v.ip, labels, info.Stats.Statistics.SourceIpPersistenceEntrySize)
 	v.NetworkMetrics.Populate(labels, info.Stats.Statistics)
+	if info.Stats.LastUpdateTimestamp != nil {
+		set(v.lastUpdate, labels, *info.Stats.LastUpdateTimestamp/1000)
+	}
+
+	for _, cCert := range info.Certificates {
+		if len(cCert.Certificate.Details) == 0 || cCert.Certificate.Details[0].NotAfter == nil {
+			continue
+		}
+		leaf := cCert.Certificate.Details[0]
+		sni := ""
+		if cCert.Type == "sni" {
+			sni = strings.Join(cCert.Names, ",")
+		}
+		certLabels := slice(
+			labels,
+			zero(info.Config.IpAddress),
+			strings.Join(info.Config.Ports, ","),
+			zero(cCert.Certificate.Id),
+			cCert.Type,
+			sni,
+		)
+		set(v.certificate, certLabels, *leaf.NotAfter/1000)
+	}
 }